@@ -0,0 +1,55 @@
+package beo
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// BatchResult adalah hasil satu pertanyaan dalam AskBatch
+type BatchResult struct {
+	// Index adalah urutan pertanyaan dalam input, mulai dari 0
+	Index    int
+	Query    string
+	Response Response
+	// Err berisi error dari Respond, misalnya jika ctx dibatalkan
+	Err error
+}
+
+// AskBatch menjawab banyak pertanyaan secara bersamaan dengan sejumlah worker
+// dan mengembalikan hasil sesuai urutan input. Jika workers <= 0, jumlah CPU
+// dipakai. Pertanyaan dijawab tanpa sesi, dan knowledge base tidak boleh
+// diubah selama AskBatch berjalan. Pertanyaan yang belum dijawab saat ctx
+// dibatalkan berisi error dari ctx.
+func (ai *AI) AskBatch(ctx context.Context, queries []string, workers int) []BatchResult {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, max(len(queries), 1))
+
+	results := make([]BatchResult, len(queries))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				response, err := ai.Respond(ctx, queries[i])
+				results[i] = BatchResult{Index: i, Query: queries[i], Response: response, Err: err}
+			}
+		}()
+	}
+
+	for i := range queries {
+		if err := ctx.Err(); err != nil {
+			results[i] = BatchResult{Index: i, Query: queries[i], Err: err}
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
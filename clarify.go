@@ -0,0 +1,164 @@
+package beo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// defaultClarificationTemplate adalah template klarifikasi jika Clarification.Template kosong
+const defaultClarificationTemplate = `Did you mean:{{range .Candidates}} {{.Number}}) {{.Question}}{{end}}`
+
+// defaultClarificationLimit adalah jumlah maksimum kandidat klarifikasi bawaan
+const defaultClarificationLimit = 3
+
+// Clarification mengatur pertanyaan balik "maksud Anda ..." ketika beberapa
+// pertanyaan memiliki skor yang hampir sama
+type Clarification struct {
+	// Margin adalah selisih skor maksimum agar kandidat dianggap ambigu, 0 menonaktifkan klarifikasi
+	Margin float64 `yaml:"margin,omitempty"`
+	// Template adalah text/template untuk pesan klarifikasi
+	Template string `yaml:"template,omitempty"`
+	// Limit adalah jumlah maksimum kandidat yang ditampilkan (bawaan 3)
+	Limit int `yaml:"limit,omitempty"`
+}
+
+// ClarificationData adalah data yang tersedia bagi template klarifikasi
+type ClarificationData struct {
+	Input      string
+	Candidates []ClarificationCandidate
+}
+
+// ClarificationCandidate adalah salah satu pertanyaan yang ditawarkan, bernomor mulai dari 1
+type ClarificationCandidate struct {
+	Number   int
+	Question string
+}
+
+// limit mengembalikan jumlah maksimum kandidat klarifikasi
+func (c Clarification) limit() int {
+	if c.Limit < 2 {
+		return defaultClarificationLimit
+	}
+	return c.Limit
+}
+
+// parseTemplate mem-parsing template klarifikasi atau template bawaan
+func (c Clarification) parseTemplate() (*template.Template, error) {
+	text := c.Template
+	if text == "" {
+		text = defaultClarificationTemplate
+	}
+	return template.New("clarification").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+}
+
+// SetClarification mengganti konfigurasi klarifikasi. Template yang tidak valid ditolak.
+func (ai *AI) SetClarification(clarification Clarification) error {
+	if _, err := clarification.parseTemplate(); err != nil {
+		return fmt.Errorf("invalid clarification template: %w", err)
+	}
+	ai.KnowledgeBase.Clarification = clarification
+	return nil
+}
+
+// clarificationCandidates menggabungkan pertanyaan terbaik dan alternatifnya,
+// tanpa duplikat dan dibatasi sesuai Limit
+func (kb *KnowledgeBase) clarificationCandidates(m match) []Question {
+	candidates := []Question{m.question}
+	for _, alternative := range m.alternatives {
+		if len(candidates) >= kb.Clarification.limit() {
+			break
+		}
+		if alternative.Question != m.question.Question {
+			candidates = append(candidates, alternative)
+		}
+	}
+	return candidates
+}
+
+// renderClarification menghasilkan pesan klarifikasi untuk kandidat pertanyaan
+func (ai *AI) renderClarification(ctx context.Context, input string, candidates []Question) (string, error) {
+	tmpl, err := ai.KnowledgeBase.Clarification.parseTemplate()
+	if err != nil {
+		return "", fmt.Errorf("gagal mem-parsing template klarifikasi: %w", err)
+	}
+
+	data := ClarificationData{Input: input}
+	for i, candidate := range candidates {
+		data.Candidates = append(data.Candidates, ClarificationCandidate{Number: i + 1, Question: candidate.Question})
+	}
+
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, data); err != nil {
+		return "", fmt.Errorf("gagal menjalankan template klarifikasi: %w", err)
+	}
+	return ai.processPlaceholders(ctx, builder.String())
+}
+
+// ordinalWords memetakan kata urutan bahasa Inggris dan Indonesia ke nomor kandidat.
+// Nilai -1 berarti kandidat terakhir.
+var ordinalWords = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5,
+	"1st": 1, "2nd": 2, "3rd": 3, "4th": 4, "5th": 5, "last": -1,
+	"pertama": 1, "kedua": 2, "ketiga": 3, "keempat": 4, "kelima": 5, "terakhir": -1,
+}
+
+// selectionFillers adalah kata yang boleh menyertai nomor atau kata urutan pada
+// jawaban lanjutan ("the second one", "yang kedua", "nomor 2 saja")
+var selectionFillers = map[string]bool{
+	"the": true, "one": true, "number": true, "no": true, "option": true, "choice": true,
+	"i": true, "mean": true, "meant": true, "want": true, "please": true, "that": true,
+	"yang": true, "nomor": true, "pilihan": true, "maksud": true, "saya": true, "mau": true,
+	"itu": true, "saja": true, "aja": true, "ya": true, "dong": true, "tolong": true,
+}
+
+// chooseCandidate mencari pilihan kandidat pada jawaban lanjutan seperti
+// "the second one", "yang kedua", atau "2". Input hanya dianggap pilihan jika
+// berisi tepat satu nomor atau kata urutan dan selebihnya kata pengisi, atau
+// jika sama dengan salah satu kandidat, sehingga pertanyaan baru seperti
+// "opening hours on day 1" tetap dicocokkan seperti biasa. Jika tidak ada
+// pilihan yang dikenali, false dikembalikan.
+func chooseCandidate(input string, candidates []string) (string, bool) {
+	tokens := tokenize(input)
+
+	// Pengguna juga dapat mengetik ulang pertanyaan yang ditawarkan
+	normalized := strings.Join(tokens, " ")
+	for _, candidate := range candidates {
+		if strings.Join(tokenize(candidate), " ") == normalized {
+			return candidate, true
+		}
+	}
+
+	selection := 0
+	for _, token := range tokens {
+		if selectionFillers[token] {
+			continue
+		}
+		number, ok := ordinalWords[token]
+		if !ok {
+			var err error
+			if number, err = strconv.Atoi(token); err != nil {
+				return "", false
+			}
+		}
+		if selection != 0 {
+			return "", false
+		}
+		selection = number
+	}
+
+	if selection == -1 {
+		selection = len(candidates)
+	}
+	if selection >= 1 && selection <= len(candidates) {
+		return candidates[selection-1], true
+	}
+	return "", false
+}
+
+// findQuestion mencari indeks pertanyaan dengan teks yang sama persis, atau -1
+func (kb *KnowledgeBase) findQuestion(text string) int {
+	return findQuestion(kb.Questions, text)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Ismananda/beo"
+)
+
+// batchRecord adalah satu baris hasil batch
+type batchRecord struct {
+	Index       int       `json:"index"`
+	Query       string    `json:"query"`
+	Answer      string    `json:"answer"`
+	Fallback    string    `json:"fallback,omitempty"`
+	QuestionIDs []int     `json:"question_ids"`
+	Questions   []string  `json:"questions"`
+	Scores      []float64 `json:"scores"`
+	Error       string    `json:"error,omitempty"`
+}
+
+func setupBatch(fs *flag.FlagSet) func(c *cli, args []string) error {
+	inputFormat := fs.String("input-format", "", "input format: text, csv or jsonl (default from file extension, text for stdin)")
+	format := fs.String("format", "jsonl", "output format: jsonl or csv")
+	output := fs.String("output", "", "write results to this file instead of stdout")
+	field := fs.String("field", "query", "CSV column or JSONL field that holds the query")
+	workers := fs.Int("workers", 0, "number of concurrent workers (default: number of CPUs)")
+
+	return func(c *cli, args []string) error {
+		if len(args) > 1 {
+			return usageError{"batch takes at most one input file"}
+		}
+		if *format != "jsonl" && *format != "csv" {
+			return usageError{fmt.Sprintf("unknown output format %q", *format)}
+		}
+
+		input, name := io.Reader(os.Stdin), ""
+		if len(args) == 1 && args[0] != "-" {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			input, name = file, args[0]
+		}
+
+		kind := *inputFormat
+		if kind == "" {
+			kind = formatFromExtension(name)
+		}
+		queries, err := readQueries(input, kind, *field)
+		if err != nil {
+			return err
+		}
+
+		out := c.stdout
+		if *output != "" {
+			file, err := os.Create(*output)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+
+		results := c.ai.AskBatch(context.Background(), queries, *workers)
+		records := make([]batchRecord, len(results))
+		for i, result := range results {
+			records[i] = newBatchRecord(result)
+		}
+		if *format == "csv" {
+			return writeBatchCSV(out, records)
+		}
+		return writeBatchJSONL(out, records)
+	}
+}
+
+// formatFromExtension menebak format input dari ekstensi file
+func formatFromExtension(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return "csv"
+	case ".jsonl", ".ndjson":
+		return "jsonl"
+	}
+	return "text"
+}
+
+// readQueries membaca pertanyaan dari input teks (satu per baris), CSV dengan
+// header, atau JSONL berisi objek atau string
+func readQueries(r io.Reader, kind, field string) ([]string, error) {
+	var queries []string
+	switch kind {
+	case "text":
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				queries = append(queries, line)
+			}
+		}
+		return queries, scanner.Err()
+
+	case "csv":
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+		column := -1
+		for i, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), field) {
+				column = i
+			}
+		}
+		if column < 0 {
+			return nil, fmt.Errorf("CSV header has no %q column", field)
+		}
+		for {
+			row, err := reader.Read()
+			if err == io.EOF {
+				return queries, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if column < len(row) && strings.TrimSpace(row[column]) != "" {
+				queries = append(queries, row[column])
+			}
+		}
+
+	case "jsonl":
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+			query, err := jsonQuery([]byte(text), field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			queries = append(queries, query)
+		}
+		return queries, scanner.Err()
+	}
+	return nil, fmt.Errorf("unknown input format %q", kind)
+}
+
+// jsonQuery mengambil pertanyaan dari satu baris JSONL
+func jsonQuery(data []byte, field string) (string, error) {
+	var query string
+	if err := json.Unmarshal(data, &query); err == nil {
+		return query, nil
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil {
+		return "", err
+	}
+	query, ok := object[field].(string)
+	if !ok {
+		return "", fmt.Errorf("missing string field %q", field)
+	}
+	return query, nil
+}
+
+// newBatchRecord meringkas hasil AskBatch menjadi satu baris keluaran
+func newBatchRecord(result beo.BatchResult) batchRecord {
+	record := batchRecord{
+		Index:       result.Index,
+		Query:       result.Query,
+		Answer:      result.Response.Text,
+		Fallback:    result.Response.Fallback,
+		QuestionIDs: []int{},
+		Questions:   []string{},
+		Scores:      []float64{},
+	}
+	if result.Err != nil {
+		record.Error = result.Err.Error()
+	}
+	for _, answer := range result.Response.Answers {
+		record.QuestionIDs = append(record.QuestionIDs, answer.ID)
+		record.Questions = append(record.Questions, answer.Question)
+		record.Scores = append(record.Scores, answer.Score)
+	}
+	return record
+}
+
+// writeBatchJSONL menulis satu objek JSON per baris
+func writeBatchJSONL(w io.Writer, records []batchRecord) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeBatchCSV menulis hasil sebagai CSV. Beberapa pertanyaan dalam satu
+// baris dipisahkan dengan titik koma.
+func writeBatchCSV(w io.Writer, records []batchRecord) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"index", "query", "answer", "fallback", "question_ids", "questions", "scores", "error"})
+	for _, record := range records {
+		ids := make([]string, len(record.QuestionIDs))
+		for i, id := range record.QuestionIDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		scores := make([]string, len(record.Scores))
+		for i, score := range record.Scores {
+			scores[i] = strconv.FormatFloat(score, 'f', 4, 64)
+		}
+		writer.Write([]string{
+			strconv.Itoa(record.Index), record.Query, record.Answer, record.Fallback,
+			strings.Join(ids, ";"), strings.Join(record.Questions, ";"), strings.Join(scores, ";"), record.Error,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test readQueries membaca teks, CSV, dan JSONL serta melewati baris kosong
+func TestReadQueries(t *testing.T) {
+	tests := []struct {
+		kind     string
+		field    string
+		input    string
+		expected []string
+	}{
+		{"text", "query", "where is the office\n\n  what is your name  \n", []string{"where is the office", "what is your name"}},
+		{"csv", "query", "id,Query\n1,where is the office\n2,\n3,\"hello, beo\"\n4\n", []string{"where is the office", "hello, beo"}},
+		{"csv", "text", "id,text\n1,ping\n", []string{"ping"}},
+		{"jsonl", "query", "{\"query\": \"ping\"}\n\n\"plain string\"\n{\"query\": \"pong\", \"id\": 2}\n", []string{"ping", "plain string", "pong"}},
+		{"jsonl", "text", "{\"text\": \"ping\"}\n", []string{"ping"}},
+	}
+	for _, test := range tests {
+		queries, err := readQueries(strings.NewReader(test.input), test.kind, test.field)
+		if err != nil {
+			t.Errorf("For %s %q unexpected error: %v", test.kind, test.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(queries, test.expected) {
+			t.Errorf("For %s %q expected %q, but got %q", test.kind, test.input, test.expected, queries)
+		}
+	}
+}
+
+// Test readQueries melaporkan kolom yang hilang, baris JSONL tidak valid, dan format tidak dikenal
+func TestReadQueriesErrors(t *testing.T) {
+	tests := []struct {
+		kind     string
+		field    string
+		input    string
+		expected string
+	}{
+		{"csv", "query", "id,text\n1,ping\n", `CSV header has no "query" column`},
+		{"csv", "query", "", "failed to read CSV header"},
+		{"jsonl", "query", "{\"query\": \"ping\"}\n{\"query\": \n", "line 2"},
+		{"jsonl", "query", "{\"query\": \"ping\"}\n\n{\"text\": \"pong\"}\n", `line 3: missing string field "query"`},
+		{"xml", "query", "<query/>", `unknown input format "xml"`},
+	}
+	for _, test := range tests {
+		_, err := readQueries(strings.NewReader(test.input), test.kind, test.field)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("For %s %q expected an error containing %q, but got %v", test.kind, test.input, test.expected, err)
+		}
+	}
+}
+
+// Test jsonQuery menerima string JSON atau objek dengan field pertanyaan
+func TestJSONQuery(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{`"where is the office"`, "where is the office", true},
+		{`{"query": "ping", "id": 1}`, "ping", true},
+		{`{"query": 42}`, "", false},
+		{`{"text": "ping"}`, "", false},
+		{`["ping"]`, "", false},
+		{`{"query": `, "", false},
+	}
+	for _, test := range tests {
+		query, err := jsonQuery([]byte(test.input), "query")
+		if (err == nil) != test.valid {
+			t.Errorf("For %s expected valid=%v, but got error %v", test.input, test.valid, err)
+			continue
+		}
+		if query != test.expected {
+			t.Errorf("For %s expected %q, but got %q", test.input, test.expected, query)
+		}
+	}
+}
+
+// Test writeBatchCSV menulis header dan menggabungkan beberapa pertanyaan dengan titik koma
+func TestWriteBatchCSV(t *testing.T) {
+	records := []batchRecord{
+		{
+			Index: 0, Query: "name, office", Answer: "I am Beo. Jakarta.",
+			QuestionIDs: []int{1, 2}, Questions: []string{"What is your name?", "Where is the office?"},
+			Scores: []float64{1, 0.87654},
+		},
+		{
+			Index: 1, Query: "qwerty", Answer: "I'm sorry.", Fallback: "noanswer",
+			QuestionIDs: []int{}, Questions: []string{}, Scores: []float64{},
+		},
+		{Index: 2, Query: "slow", Error: "context deadline exceeded"},
+	}
+
+	var b bytes.Buffer
+	if err := writeBatchCSV(&b, records); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "index,query,answer,fallback,question_ids,questions,scores,error\n" +
+		"0,\"name, office\",I am Beo. Jakarta.,,1;2,What is your name?;Where is the office?,1.0000;0.8765,\n" +
+		"1,qwerty,I'm sorry.,noanswer,,,,\n" +
+		"2,slow,,,,,,context deadline exceeded\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, b.String())
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Ismananda/beo"
+	"golang.org/x/term"
+)
+
+const chatHelp = `Commands:
+  :explain <question>              explain how the answer is chosen
+  :train <question> => <a> || <b>  train a question and save the model
+  :set <name> <value>              set a session variable
+  :reload                          reload the model file
+  :seed <number>                   make answer choices repeatable
+  :history                         show previous inputs
+  :help                            show this help
+  :quit                            leave the chat
+`
+
+// Kode warna ANSI untuk keluaran terminal
+const (
+	colorReset  = "\033[0m"
+	colorAnswer = "\033[36m"
+	colorNote   = "\033[2m"
+	colorError  = "\033[31m"
+)
+
+// chat adalah sesi REPL interaktif di atas satu model
+type chat struct {
+	ai      *beo.AI
+	file    *os.File
+	session *beo.Session
+	history []string
+	color   bool
+	out     io.Writer
+}
+
+// runChat menjalankan REPL sampai pengguna keluar atau input habis.
+// Jika stdin adalah terminal, baris dapat diedit dan riwayat dapat dipanggil
+// dengan tombol panah, dan keluaran diberi warna.
+func runChat(ai *beo.AI, file *os.File) error {
+	c := &chat{ai: ai, file: file, session: beo.NewSession(), out: os.Stdout}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if !c.handle(scanner.Text()) {
+				return nil
+			}
+		}
+		return scanner.Err()
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, "> ")
+	c.out = terminal
+	c.color = os.Getenv("NO_COLOR") == ""
+
+	fmt.Fprintf(c.out, "Chatting with %s. Type :help for commands.\n", ai.KnowledgeBase.AIName)
+	for {
+		line, err := terminal.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !c.handle(line) {
+			return nil
+		}
+	}
+}
+
+// print menulis satu baris dengan warna jika terminal mendukung
+func (c *chat) print(color, text string) {
+	if c.color {
+		text = color + text + colorReset
+	}
+	fmt.Fprintln(c.out, text)
+}
+
+// handle memproses satu baris input dan mengembalikan false jika chat selesai
+func (c *chat) handle(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return true
+	}
+	if line != ":history" {
+		c.history = append(c.history, line)
+	}
+
+	if !strings.HasPrefix(line, ":") {
+		c.ask(line)
+		return true
+	}
+
+	command, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	switch command {
+	case ":quit", ":exit", ":q":
+		return false
+	case ":help":
+		fmt.Fprint(c.out, chatHelp)
+	case ":explain":
+		explanation, err := c.ai.Explain(beo.WithSession(context.Background(), c.session), arg)
+		if err != nil {
+			c.print(colorError, err.Error())
+			return true
+		}
+		fmt.Fprint(c.out, explanation)
+	case ":train":
+		c.train(arg)
+	case ":set":
+		name, value, ok := strings.Cut(arg, " ")
+		if !ok || name == "" {
+			c.print(colorError, "Usage: :set <name> <value>")
+			return true
+		}
+		c.session.Set(name, strings.TrimSpace(value))
+	case ":reload":
+		c.reload()
+	case ":seed":
+		seed, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			c.print(colorError, "Usage: :seed <number>")
+			return true
+		}
+		beo.Seed(seed)
+	case ":history":
+		for i, entry := range c.history {
+			fmt.Fprintf(c.out, "%3d  %s\n", i+1, entry)
+		}
+	default:
+		c.print(colorError, fmt.Sprintf("Unknown command %s. Type :help for commands.", command))
+	}
+	return true
+}
+
+// ask menjawab pertanyaan dalam sesi chat
+func (c *chat) ask(question string) {
+	response, err := c.ai.Respond(beo.WithSession(context.Background(), c.session), question)
+	if err != nil {
+		c.print(colorError, err.Error())
+		return
+	}
+	if response.Corrected != "" {
+		c.print(colorNote, fmt.Sprintf("(showing results for %q)", response.Corrected))
+	}
+	c.print(colorAnswer, response.Text)
+}
+
+// train melatih pertanyaan dengan format "pertanyaan => jawaban | jawaban"
+func (c *chat) train(arg string) {
+	question, answers, ok := strings.Cut(arg, "=>")
+	question = strings.TrimSpace(question)
+	if !ok || question == "" {
+		c.print(colorError, "Usage: :train <question> => <answer> || <answer>")
+		return
+	}
+
+	// Jawaban dipisahkan dengan "||" karena "|" dipakai oleh variasi {a|b}
+	var list []string
+	for _, answer := range strings.Split(answers, "||") {
+		if answer = strings.TrimSpace(answer); answer != "" {
+			list = append(list, answer)
+		}
+	}
+	if len(list) == 0 {
+		c.print(colorError, "Please provide at least one answer.")
+		return
+	}
+
+	c.ai.Train(question, list, "")
+	if err := c.ai.Save(); err != nil {
+		c.print(colorError, fmt.Sprintf("Failed to save model: %v", err))
+		return
+	}
+	c.print(colorNote, "Model successfully trained.")
+}
+
+// reload memuat ulang model dari file, misalnya setelah diedit di luar chat
+func (c *chat) reload() {
+	if _, err := c.file.Seek(0, io.SeekStart); err != nil {
+		c.print(colorError, fmt.Sprintf("Failed to reload model: %v", err))
+		return
+	}
+	ai, err := beo.NewAI(c.file)
+	if err != nil {
+		c.print(colorError, fmt.Sprintf("Failed to reload model: %v", err))
+		return
+	}
+	c.ai = ai
+	c.print(colorNote, fmt.Sprintf("Reloaded %d questions.", len(ai.KnowledgeBase.Questions)))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test :train memisahkan jawaban dengan "||" sehingga variasi {a|b} tetap utuh
+func TestChatTrain(t *testing.T) {
+	file, err := os.OpenFile(filepath.Join(t.TempDir(), "model.yml"), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		t.Fatalf("Error creating model file: %v", err)
+	}
+	defer file.Close()
+
+	ai, err := beo.NewAI(file)
+	if err != nil {
+		t.Fatalf("Error initializing AI: %v", err)
+	}
+	var out bytes.Buffer
+	c := &chat{ai: ai, file: file, session: beo.NewSession(), out: &out}
+
+	c.handle(":train hi => {Hello|Hi} there || Hey ||")
+	questions := ai.KnowledgeBase.Questions
+	if len(questions) != 1 || questions[0].Question != "hi" {
+		t.Fatalf("Expected question %q, but got %+v (output %q)", "hi", questions, out.String())
+	}
+	expected := []string{"{Hello|Hi} there", "Hey"}
+	if !reflect.DeepEqual(questions[0].Answers, expected) {
+		t.Errorf("Expected answers %q, but got %q", expected, questions[0].Answers)
+	}
+
+	// Model tersimpan ke file
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatalf("Error reading model file: %v", err)
+	}
+	if !bytes.Contains(data, []byte("{Hello|Hi} there")) {
+		t.Errorf("Expected saved model to contain the answer, but got %s", data)
+	}
+
+	out.Reset()
+	c.handle(":train hello")
+	if !bytes.Contains(out.Bytes(), []byte("Usage: :train")) {
+		t.Errorf("Expected usage message, but got %q", out.String())
+	}
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Ismananda/beo"
+)
+
+// stringList adalah flag yang dapat diberikan berkali-kali, misalnya --tag a --tag b
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// commands adalah seluruh subcommand CLI
+var commands = map[string]command{}
+
+func init() {
+	for _, cmd := range []command{
+		{name: "ask", args: "<question>", summary: "Ask the model a question.", setup: setupAsk},
+		{name: "chat", summary: "Start an interactive chat with a persistent session.", writes: true, setup: setupChat},
+		{name: "batch", args: "[file]", summary: "Answer queries from a text, CSV or JSONL file (or stdin) concurrently.", setup: setupBatch},
+		{name: "explain", args: "<question>", summary: "Explain how the answer to a question is chosen.", setup: setupExplain},
+		{name: "search", args: "<query>", summary: "List the questions most similar to a query.", setup: setupSearch},
+		{name: "synonyms", args: "<query>", summary: "Show synonyms in a query that are replaced when matching.", setup: setupSynonyms},
+		{name: "train", args: "<question> [answer...]", summary: "Add a question with answers or a hook.", writes: true, setup: setupTrain},
+		{name: "import", args: "<file...>", summary: "Import questions from CSV, JSONL or Markdown FAQ files.", writes: true, setup: setupImport},
+		{name: "hook", args: "<name> <answer...>", summary: "Add a hook with answers.", writes: true, setup: setupHook},
+		{name: "placeholder", args: "<name> <value>", summary: "Add a static placeholder.", writes: true, setup: setupPlaceholder},
+		{name: "list", summary: "List trained questions with their IDs.", setup: setupList},
+		{name: "rm", args: "<id|question...>", summary: "Remove questions by ID (from list) or by text.", writes: true, setup: setupRemove},
+		{name: "lint", summary: "Check the model for problems.", setup: setupLint},
+	} {
+		commands[cmd.name] = cmd
+	}
+}
+
+func setupAsk(fs *flag.FlagSet) func(c *cli, args []string) error {
+	explain := fs.Bool("explain", false, "explain how the answer is chosen")
+	return func(c *cli, args []string) error {
+		question, err := joinArgs(args, "a question")
+		if err != nil {
+			return err
+		}
+		if *explain {
+			return c.explain(question)
+		}
+		response, err := c.ai.Respond(context.Background(), question)
+		if err != nil {
+			return err
+		}
+		if c.json {
+			return c.printJSON(response)
+		}
+		fmt.Fprintln(c.stdout, response.Text)
+		return nil
+	}
+}
+
+func setupChat(fs *flag.FlagSet) func(c *cli, args []string) error {
+	return func(c *cli, args []string) error {
+		if len(args) > 0 {
+			return usageError{"chat takes no arguments"}
+		}
+		return runChat(c.ai, c.file)
+	}
+}
+
+func setupExplain(fs *flag.FlagSet) func(c *cli, args []string) error {
+	return func(c *cli, args []string) error {
+		question, err := joinArgs(args, "a question")
+		if err != nil {
+			return err
+		}
+		return c.explain(question)
+	}
+}
+
+// explain mencetak penjelasan pemilihan jawaban untuk ask --explain dan explain
+func (c *cli) explain(question string) error {
+	explanation, err := c.ai.Explain(context.Background(), question)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(explanation)
+	}
+	fmt.Fprint(c.stdout, explanation)
+	return nil
+}
+
+func setupSearch(fs *flag.FlagSet) func(c *cli, args []string) error {
+	k := fs.Int("k", 5, "number of questions to show")
+	return func(c *cli, args []string) error {
+		query, err := joinArgs(args, "a query")
+		if err != nil {
+			return err
+		}
+		if *k <= 0 {
+			return usageError{"--k must be positive"}
+		}
+
+		type result struct {
+			ID       int     `json:"id"`
+			Question string  `json:"question"`
+			Score    float64 `json:"score"`
+		}
+		results := []result{}
+		for _, r := range c.ai.Search(query, *k) {
+			results = append(results, result{questionID(c.ai, r.Question.Question), r.Question.Question, r.Score})
+		}
+		if c.json {
+			return c.printJSON(results)
+		}
+		if len(results) == 0 {
+			fmt.Fprintln(c.stdout, "No matching questions.")
+			return nil
+		}
+
+		writer := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "#\tSCORE\tID\tQUESTION")
+		for i, r := range results {
+			fmt.Fprintf(writer, "%d\t%.3f\t%d\t%s\n", i+1, r.Score, r.ID, r.Question)
+		}
+		return writer.Flush()
+	}
+}
+
+func setupSynonyms(fs *flag.FlagSet) func(c *cli, args []string) error {
+	return func(c *cli, args []string) error {
+		query, err := joinArgs(args, "a query")
+		if err != nil {
+			return err
+		}
+		matches := c.ai.MatchedSynonyms(query)
+		if c.json {
+			type synonym struct {
+				Phrase    string `json:"phrase"`
+				Canonical string `json:"canonical"`
+			}
+			synonyms := []synonym{}
+			for _, match := range matches {
+				synonyms = append(synonyms, synonym{match.Phrase, match.Canonical})
+			}
+			return c.printJSON(synonyms)
+		}
+		if len(matches) == 0 {
+			fmt.Fprintln(c.stdout, "No synonyms matched.")
+			return nil
+		}
+		for _, match := range matches {
+			fmt.Fprintf(c.stdout, "%s -> %s\n", match.Phrase, match.Canonical)
+		}
+		return nil
+	}
+}
+
+func setupTrain(fs *flag.FlagSet) func(c *cli, args []string) error {
+	hook := fs.String("hook", "", "answer the question with this hook instead of fixed answers")
+	var tags stringList
+	fs.Var(&tags, "tag", "tag for topic fallbacks (repeatable)")
+	return func(c *cli, args []string) error {
+		if len(args) == 0 {
+			return usageError{"please provide a question"}
+		}
+		question, answers := args[0], args[1:]
+		if len(answers) == 0 && *hook == "" {
+			return usageError{"please provide answers or --hook"}
+		}
+
+		c.ai.Train(question, answers, *hook)
+		if len(tags) > 0 {
+			if err := c.ai.SetTags(question, tags...); err != nil {
+				return err
+			}
+		}
+		if err := c.save(); err != nil {
+			return err
+		}
+		return c.report("Model successfully trained.")
+	}
+}
+
+func setupHook(fs *flag.FlagSet) func(c *cli, args []string) error {
+	return func(c *cli, args []string) error {
+		if len(args) < 2 {
+			return usageError{"please provide a hook name and answers"}
+		}
+		c.ai.AddHook(args[0], args[1:])
+		if err := c.save(); err != nil {
+			return err
+		}
+		return c.report("Hook successfully added.")
+	}
+}
+
+func setupPlaceholder(fs *flag.FlagSet) func(c *cli, args []string) error {
+	return func(c *cli, args []string) error {
+		if len(args) != 2 {
+			return usageError{"please provide a placeholder name and its value"}
+		}
+		c.ai.AddPlaceholder(args[0], args[1])
+		if err := c.save(); err != nil {
+			return err
+		}
+		return c.report("Placeholder successfully added.")
+	}
+}
+
+func setupList(fs *flag.FlagSet) func(c *cli, args []string) error {
+	return func(c *cli, args []string) error {
+		if len(args) > 0 {
+			return usageError{"list takes no arguments"}
+		}
+
+		type entry struct {
+			ID       int      `json:"id"`
+			Question string   `json:"question"`
+			Aliases  []string `json:"aliases,omitempty"`
+			Answers  []string `json:"answers,omitempty"`
+			Hook     string   `json:"hook,omitempty"`
+			Tags     []string `json:"tags,omitempty"`
+		}
+		entries := []entry{}
+		for i, q := range c.ai.KnowledgeBase.Questions {
+			entries = append(entries, entry{i + 1, q.Question, q.Aliases, q.Answers, q.Hook, q.Tags})
+		}
+		if c.json {
+			return c.printJSON(entries)
+		}
+
+		writer := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "ID\tQUESTION\tANSWERS\tTAGS")
+		for _, e := range entries {
+			answers := strconv.Itoa(len(e.Answers))
+			if e.Hook != "" {
+				answers = "hook:" + e.Hook
+			}
+			fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", e.ID, e.Question, answers, strings.Join(e.Tags, ","))
+		}
+		return writer.Flush()
+	}
+}
+
+func setupRemove(fs *flag.FlagSet) func(c *cli, args []string) error {
+	return func(c *cli, args []string) error {
+		if len(args) == 0 {
+			return usageError{"please provide question IDs or texts"}
+		}
+
+		// ID diubah menjadi teks lebih dulu agar penghapusan tidak menggeser ID lain
+		questions := c.ai.KnowledgeBase.Questions
+		var targets []string
+		for _, arg := range args {
+			id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+			if err != nil {
+				targets = append(targets, arg)
+				continue
+			}
+			if id < 1 || id > len(questions) {
+				return fmt.Errorf("question ID %d not found", id)
+			}
+			targets = append(targets, questions[id-1].Question)
+		}
+
+		for _, target := range targets {
+			if err := c.ai.RemoveQuestion(target); err != nil {
+				return err
+			}
+		}
+		if err := c.save(); err != nil {
+			return err
+		}
+		return c.report(fmt.Sprintf("Removed %d question(s).", len(targets)))
+	}
+}
+
+func setupLint(fs *flag.FlagSet) func(c *cli, args []string) error {
+	return func(c *cli, args []string) error {
+		if len(args) > 0 {
+			return usageError{"lint takes no arguments"}
+		}
+
+		var problems []string
+		if err := c.ai.Validate(); err != nil {
+			problems = strings.Split(err.Error(), "\n")
+		}
+
+		if c.json {
+			if err := c.printJSON(map[string]any{"valid": len(problems) == 0, "problems": problems}); err != nil {
+				return err
+			}
+		} else if len(problems) == 0 {
+			fmt.Fprintf(c.stdout, "OK: %d questions, no problems found.\n", len(c.ai.KnowledgeBase.Questions))
+		} else {
+			for _, problem := range problems {
+				fmt.Fprintln(c.stdout, problem)
+			}
+		}
+
+		if len(problems) > 0 {
+			return exitCodeError{exitError}
+		}
+		return nil
+	}
+}
+
+// report menampilkan pesan keberhasilan, atau {"ok": true} dalam mode JSON
+func (c *cli) report(message string) error {
+	if c.json {
+		return c.printJSON(map[string]any{"ok": true, "message": message})
+	}
+	fmt.Fprintln(c.stdout, message)
+	return nil
+}
+
+// questionID mengembalikan ID pertanyaan (urutan mulai dari 1 seperti pada list), atau 0
+func questionID(ai *beo.AI, question string) int {
+	for i, q := range ai.KnowledgeBase.Questions {
+		if q.Question == question {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Ismananda/beo"
+)
+
+// importReaders memetakan format FAQ ke pembacanya
+var importReaders = map[string]func(io.Reader) ([]beo.Question, error){
+	"csv":      beo.ReadCSV,
+	"jsonl":    beo.ReadJSONL,
+	"markdown": beo.ReadMarkdownFAQ,
+}
+
+// corpusConverters memetakan format korpus chatbot lain ke pengonversinya.
+// File Rasa dikonversi bersama karena intent, response, dan rules biasanya
+// berada di file yang berbeda.
+var corpusConverters = map[string]func(files ...io.Reader) (beo.KnowledgeBase, beo.ConversionReport, error){
+	"aiml":       eachFile(beo.ConvertAIML),
+	"chatterbot": eachFile(beo.ConvertChatterBot),
+	"rasa":       beo.ConvertRasa,
+}
+
+// importFormats adalah seluruh format yang dapat diimpor
+const importFormats = "csv, jsonl, markdown, aiml, chatterbot or rasa"
+
+// importSet mengumpulkan hasil seluruh file yang diimpor
+type importSet struct {
+	questions  []beo.Question
+	hooks      map[string]beo.Hook
+	synonyms   [][]string
+	conversion *beo.ConversionReport
+}
+
+func setupImport(fs *flag.FlagSet) func(c *cli, args []string) error {
+	format := fs.String("format", "", "input format: "+importFormats+" (default from file extension)")
+	replace := fs.Bool("replace", false, "replace all questions instead of merging")
+	dryRun := fs.Bool("dry-run", false, "show what would change without saving")
+	return func(c *cli, args []string) error {
+		if len(args) == 0 {
+			return usageError{"please provide files to import"}
+		}
+
+		set, err := readImports(args, *format)
+		if err != nil {
+			return err
+		}
+
+		set.renameHooks(c.ai.KnowledgeBase.Hooks)
+
+		mode := beo.ImportMerge
+		if *replace {
+			mode = beo.ImportReplace
+		}
+		report, err := c.ai.Import(set.questions, mode, *dryRun)
+		if err != nil {
+			return err
+		}
+
+		var warnings []string
+		if !*dryRun {
+			warnings = set.apply(c.ai)
+			if err := c.save(); err != nil {
+				return err
+			}
+		}
+
+		if c.json {
+			return c.printJSON(map[string]any{
+				"dry_run":    *dryRun,
+				"mode":       mode,
+				"report":     report,
+				"conversion": set.conversion,
+				"warnings":   warnings,
+			})
+		}
+		if set.conversion != nil {
+			for _, issue := range set.conversion.Issues {
+				fmt.Fprintf(c.stdout, "! %s: %s\n", issue.Location, issue.Message)
+			}
+			fmt.Fprintf(c.stdout, "Conversion: %s.\n", set.conversion)
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(c.stdout, "! %s\n", warning)
+		}
+		if *dryRun {
+			for _, group := range []struct {
+				label     string
+				questions []string
+			}{{"+", report.Added}, {"~", report.Updated}, {"-", report.Removed}} {
+				for _, question := range group.questions {
+					fmt.Fprintf(c.stdout, "%s %s\n", group.label, question)
+				}
+			}
+			fmt.Fprintf(c.stdout, "Dry run: %s. Nothing was saved.\n", report)
+			return nil
+		}
+		fmt.Fprintf(c.stdout, "Imported %d question(s): %s.\n", len(set.questions), report)
+		return nil
+	}
+}
+
+// readImports membaca seluruh file impor. File korpus dengan format yang sama
+// dikonversi bersama.
+func readImports(paths []string, format string) (*importSet, error) {
+	set := &importSet{hooks: make(map[string]beo.Hook)}
+	corpora := make(map[string][]string)
+	var order []string
+	for _, path := range paths {
+		kind, err := importFormat(path, format)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if _, ok := corpusConverters[kind]; ok {
+			if corpora[kind] == nil {
+				order = append(order, kind)
+			}
+			corpora[kind] = append(corpora[kind], path)
+			continue
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		questions, err := importReaders[kind](file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		set.questions = append(set.questions, questions...)
+	}
+
+	for _, kind := range order {
+		if err := set.convert(kind, corpora[kind]); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// convert mengonversi file korpus chatbot lain dan menggabungkan laporannya
+func (s *importSet) convert(kind string, paths []string) error {
+	var files []io.Reader
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		files = append(files, file)
+	}
+
+	kb, report, err := corpusConverters[kind](files...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.Join(paths, ", "), err)
+	}
+
+	s.questions = append(s.questions, kb.Questions...)
+	for name, hook := range kb.Hooks {
+		s.hooks[name] = hook
+	}
+	s.synonyms = append(s.synonyms, kb.Synonyms...)
+
+	if s.conversion == nil {
+		s.conversion = &beo.ConversionReport{}
+	}
+	s.conversion.Converted += report.Converted
+	s.conversion.Skipped += report.Skipped
+	for _, issue := range report.Issues {
+		issue.Location = kind + " " + issue.Location
+		s.conversion.Issues = append(s.conversion.Issues, issue)
+	}
+	return nil
+}
+
+// renameHooks memberi nama baru pada hook hasil konversi yang namanya sudah
+// dipakai hook lain di model, lalu menyesuaikan Hook pada pertanyaan yang
+// diimpor. Hook dengan jawaban yang sama tetap memakai nama yang ada.
+func (s *importSet) renameHooks(existing map[string]beo.Hook) {
+	hooks := make(map[string]beo.Hook)
+	renamed := make(map[string]string)
+	for name, hook := range s.hooks {
+		// Nama sudah dipakai jika dipakai hook impor lain atau hook model dengan jawaban berbeda
+		taken := func(candidate string) bool {
+			if _, ok := hooks[candidate]; ok {
+				return true
+			}
+			if _, ok := s.hooks[candidate]; ok && candidate != name {
+				return true
+			}
+			current, ok := existing[candidate]
+			return ok && !slices.Equal(current.Answers, hook.Answers)
+		}
+		newName := name
+		for n := 2; taken(newName); n++ {
+			newName = fmt.Sprintf("%s_%d", name, n)
+		}
+		hooks[newName] = hook
+		renamed[name] = newName
+	}
+	s.hooks = hooks
+	for i, q := range s.questions {
+		if newName, ok := renamed[q.Hook]; ok {
+			s.questions[i].Hook = newName
+		}
+	}
+}
+
+// apply menambahkan hook dan sinonim hasil konversi ke model
+func (s *importSet) apply(ai *beo.AI) []string {
+	var warnings []string
+	for name, hook := range s.hooks {
+		ai.AddHook(name, hook.Answers)
+	}
+	if len(s.synonyms) > 0 {
+		synonyms := append(ai.KnowledgeBase.Synonyms, s.synonyms...)
+		if err := ai.SetSynonyms(synonyms); err != nil {
+			warnings = append(warnings, fmt.Sprintf("synonyms were not imported: %v", err))
+		}
+	}
+	return warnings
+}
+
+// importFormat mengembalikan format file impor, ditebak dari ekstensi jika tidak diatur
+func importFormat(path, format string) (string, error) {
+	if format != "" {
+		_, reader := importReaders[format]
+		_, converter := corpusConverters[format]
+		if !reader && !converter {
+			return "", fmt.Errorf("unknown import format %q (expected %s)", format, importFormats)
+		}
+		return format, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "csv", nil
+	case ".jsonl", ".ndjson":
+		return "jsonl", nil
+	case ".md", ".markdown":
+		return "markdown", nil
+	case ".aiml":
+		return "aiml", nil
+	}
+	return "", fmt.Errorf("cannot detect format from extension (use --format)")
+}
+
+// eachFile menjalankan pengonversi satu file untuk setiap file dan menggabungkan hasilnya
+func eachFile(convert func(io.Reader) (beo.KnowledgeBase, beo.ConversionReport, error)) func(files ...io.Reader) (beo.KnowledgeBase, beo.ConversionReport, error) {
+	return func(files ...io.Reader) (beo.KnowledgeBase, beo.ConversionReport, error) {
+		merged := beo.KnowledgeBase{Hooks: make(map[string]beo.Hook)}
+		var report beo.ConversionReport
+		for i, file := range files {
+			kb, fileReport, err := convert(file)
+			if err != nil {
+				return beo.KnowledgeBase{}, beo.ConversionReport{}, err
+			}
+			// Hook dengan nama yang sama dari file lain diberi nama baru
+			renamed := make(map[string]string)
+			for name, hook := range kb.Hooks {
+				newName := name
+				for n := 2; merged.Hooks[newName].Answers != nil; n++ {
+					newName = fmt.Sprintf("%s_%d", name, n)
+				}
+				merged.Hooks[newName] = hook
+				renamed[name] = newName
+			}
+			for _, q := range kb.Questions {
+				if newName, ok := renamed[q.Hook]; ok {
+					q.Hook = newName
+				}
+				merged.Questions = append(merged.Questions, q)
+			}
+			report.Converted += fileReport.Converted
+			report.Skipped += fileReport.Skipped
+			for _, issue := range fileReport.Issues {
+				if len(files) > 1 {
+					issue.Location = fmt.Sprintf("file %d %s", i+1, issue.Location)
+				}
+				report.Issues = append(report.Issues, issue)
+			}
+		}
+		return merged, report, nil
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test hook AIML yang namanya sudah ada di model diberi nama baru sehingga
+// hook lama tidak dipakai oleh pertanyaan yang diimpor
+func TestImportHookClash(t *testing.T) {
+	model := newTestModel(t)
+	if code, _, stderr := runCLI(t, "hook", "hello", "I am the status hook"); code != exitOK {
+		t.Fatalf("Expected exit code %d, but got %d: %s", exitOK, code, stderr)
+	}
+
+	path := filepath.Join(t.TempDir(), "bot.aiml")
+	input := `<aiml version="2.0">
+  <category><pattern>HELLO</pattern><template>Hi there!</template></category>
+  <category><pattern>HI</pattern><template><srai>HELLO</srai></template></category>
+</aiml>`
+	if err := os.WriteFile(path, []byte(input), 0644); err != nil {
+		t.Fatalf("Error writing %s: %v", path, err)
+	}
+
+	// Impor kedua memakai ulang hook hasil impor pertama karena jawabannya sama
+	for i := 0; i < 2; i++ {
+		if code, _, stderr := runCLI(t, "import", path); code != exitOK {
+			t.Fatalf("Expected exit code %d, but got %d: %s", exitOK, code, stderr)
+		}
+	}
+
+	for _, input := range []string{"hi", "hello"} {
+		if _, stdout, _ := runCLI(t, "ask", input); stdout != "Hi there!\n" {
+			t.Errorf("For %q expected %q, but got %q", input, "Hi there!\n", stdout)
+		}
+	}
+
+	file, err := os.Open(model)
+	if err != nil {
+		t.Fatalf("Error opening model: %v", err)
+	}
+	defer file.Close()
+	ai, err := beo.NewAI(file)
+	if err != nil {
+		t.Fatalf("Error loading model: %v", err)
+	}
+	expected := map[string]beo.Hook{
+		"hello":   {Answers: []string{"I am the status hook"}},
+		"hello_2": {Answers: []string{"Hi there!"}},
+	}
+	if !reflect.DeepEqual(ai.KnowledgeBase.Hooks, expected) {
+		t.Errorf("Expected hooks %v, but got %v", expected, ai.KnowledgeBase.Hooks)
+	}
+}
@@ -1,107 +1,248 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/Ismananda/beo"
 )
 
+// Kode keluar CLI
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// defaultModel adalah file model jika --model dan BEO_MODEL tidak diatur
+const defaultModel = "model.yml"
+
+// command adalah satu subcommand CLI. setup mendaftarkan flag khusus
+// subcommand dan mengembalikan fungsi yang menjalankannya.
+type command struct {
+	name    string
+	args    string
+	summary string
+	// writes bernilai true jika subcommand mengubah model, sehingga file
+	// model dibuat jika belum ada
+	writes bool
+	setup  func(fs *flag.FlagSet) func(c *cli, args []string) error
+}
+
+// cli menyimpan keadaan bersama untuk subcommand yang sedang berjalan
+type cli struct {
+	ai     *beo.AI
+	file   *os.File
+	json   bool
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// usageError menandai argumen yang salah, sehingga bantuan subcommand ditampilkan
+type usageError struct{ message string }
+
+func (e usageError) Error() string { return e.message }
+
+// exitCodeError mengakhiri CLI dengan kode tertentu tanpa pesan tambahan
+type exitCodeError struct{ code int }
+
+func (e exitCodeError) Error() string { return fmt.Sprintf("exit code %d", e.code) }
+
 func main() {
-	const filename = "model.yml"
-	const help = `
-Use --ask, --train, --hook, or --placeholder
-Examples:
---ask "What is AI?"
---train "What is AI?" "Artificial Intelligence"
---hook "greet" "Hello" "Hi"
---placeholder "date" "02 Jan 2006"
-`
-
-	if len(os.Args) < 2 {
-		fmt.Print(help)
-		return
-	}
-
-	file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0644)
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run menjalankan CLI dan mengembalikan kode keluar
+func run(args []string, stdout, stderr io.Writer) int {
+	model := os.Getenv("BEO_MODEL")
+	if model == "" {
+		model = defaultModel
+	}
+	jsonOutput := false
+
+	global := flag.NewFlagSet("beo", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	addCommonFlags(global, &model, &jsonOutput)
+	global.Usage = func() { printUsage(stderr) }
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if global.NArg() == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	name, rest := global.Arg(0), global.Args()[1:]
+	if name == "help" {
+		return runHelp(rest, stdout, stderr)
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "Unknown command %q.\n", name)
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("beo "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addCommonFlags(fs, &model, &jsonOutput)
+	runCommand := cmd.setup(fs)
+	fs.Usage = func() { printCommandUsage(stderr, cmd, fs) }
+
+	positional, err := parseArgs(fs, rest)
 	if err != nil {
-		fmt.Printf("Failed to open file: %v\n", err)
-		return
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	file, err := openModel(model, cmd.writes)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitError
 	}
 	defer file.Close()
 
 	ai, err := beo.NewAI(file)
 	if err != nil {
-		fmt.Printf("Failed to load model: %v\n", err)
-		return
+		fmt.Fprintf(stderr, "Error: failed to load model: %v\n", err)
+		return exitError
 	}
 
-	switch os.Args[1] {
-	case "--ask":
-		if len(os.Args) < 3 {
-			fmt.Println("Please provide a question.")
-			return
+	c := &cli{ai: ai, file: file, json: jsonOutput, stdout: stdout, stderr: stderr}
+	err = runCommand(c, positional)
+
+	var usage usageError
+	var exitCode exitCodeError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &usage):
+		fmt.Fprintf(stderr, "Error: %s\n\n", usage.message)
+		fs.Usage()
+		return exitUsage
+	case errors.As(err, &exitCode):
+		return exitCode.code
+	default:
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitError
+	}
+}
+
+// addCommonFlags mendaftarkan flag yang berlaku untuk semua subcommand
+func addCommonFlags(fs *flag.FlagSet, model *string, jsonOutput *bool) {
+	fs.StringVar(model, "model", *model, "model file (or set BEO_MODEL)")
+	fs.BoolVar(jsonOutput, "json", *jsonOutput, "print output as JSON")
+}
+
+// parseArgs mem-parsing flag yang boleh diletakkan sebelum atau sesudah argumen
+// posisi. Argumen setelah "--" selalu dianggap argumen posisi.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional, literal []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, literal = args[:i], args[i+1:]
+			break
 		}
-		question := strings.Join(os.Args[2:], " ")
-		answer := ai.Ask(question)
-		fmt.Println("Answer:", answer)
-
-	case "--train":
-		if len(os.Args) < 4 {
-			fmt.Println("Please provide a question and answers or a hook.")
-			return
+	}
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
 		}
-		question := os.Args[2]
-		lastArg := os.Args[len(os.Args)-1]
-
-		var answers []string
-		hook := ""
-		if strings.HasPrefix(lastArg, "hook:") {
-			hook = strings.TrimPrefix(lastArg, "hook:")
-			answers = os.Args[3 : len(os.Args)-1]
-		} else {
-			answers = os.Args[3:]
+		if fs.NArg() == 0 {
+			break
 		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	return append(positional, literal...), nil
+}
 
-		ai.Train(question, answers, hook)
-		if err := ai.Save(); err != nil {
-			fmt.Printf("Failed to save model: %v\n", err)
-			return
-		}
-		fmt.Println("Model successfully trained.")
+// openModel membuka file model. Subcommand yang hanya membaca tidak membuat
+// file baru, sehingga salah ketik path tidak menghasilkan model kosong.
+func openModel(path string, writes bool) (*os.File, error) {
+	if writes {
+		return os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("model %q not found (use --model or BEO_MODEL)", path)
+	}
+	return file, err
+}
 
-	case "--hook":
-		if len(os.Args) < 4 {
-			fmt.Println("Please provide a hook name and answers.")
-			return
-		}
-		hookName := os.Args[2]
-		answers := os.Args[3:]
+// runHelp menampilkan bantuan umum atau bantuan satu subcommand
+func runHelp(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stdout)
+		return exitOK
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "Unknown command %q.\n", args[0])
+		return exitUsage
+	}
 
-		ai.AddHook(hookName, answers)
-		if err := ai.Save(); err != nil {
-			fmt.Printf("Failed to save model: %v\n", err)
-			return
-		}
-		fmt.Println("Hook successfully added.")
+	model, jsonOutput := defaultModel, false
+	fs := flag.NewFlagSet("beo "+cmd.name, flag.ContinueOnError)
+	addCommonFlags(fs, &model, &jsonOutput)
+	cmd.setup(fs)
+	printCommandUsage(stdout, cmd, fs)
+	return exitOK
+}
 
-	case "--placeholder":
-		if len(os.Args) < 4 {
-			fmt.Println("Please provide a placeholder name and its value.")
-			return
-		}
-		key := os.Args[2]
-		value := os.Args[3]
+// printUsage menampilkan daftar subcommand
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: beo [--model file] [--json] <command> [arguments]\n\nCommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintf(w, "\nRun \"beo help <command>\" for details.\n")
+}
 
-		ai.AddPlaceholder(key, value)
-		if err := ai.Save(); err != nil {
-			fmt.Printf("Failed to save placeholder: %v\n", err)
-			return
-		}
-		fmt.Println("Placeholder successfully added.")
+// printCommandUsage menampilkan bantuan satu subcommand beserta flag-nya
+func printCommandUsage(w io.Writer, cmd command, fs *flag.FlagSet) {
+	fmt.Fprintf(w, "Usage: beo %s [flags] %s\n\n%s\n\nFlags:\n", cmd.name, cmd.args, cmd.summary)
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
 
-	default:
-		fmt.Print("Unknown command.", help)
+// printJSON menulis nilai sebagai JSON berindentasi
+func (c *cli) printJSON(value any) error {
+	encoder := json.NewEncoder(c.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// save menyimpan model setelah diubah
+func (c *cli) save() error {
+	if err := c.ai.Save(); err != nil {
+		return fmt.Errorf("failed to save model: %w", err)
+	}
+	return nil
+}
+
+// joinArgs menggabungkan argumen posisi menjadi satu teks, atau usageError jika kosong
+func joinArgs(args []string, what string) (string, error) {
+	text := strings.TrimSpace(strings.Join(args, " "))
+	if text == "" {
+		return "", usageError{"please provide " + what}
 	}
+	return text, nil
 }
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// runCLI menjalankan CLI dan mengembalikan kode keluar beserta stdout dan stderr
+func runCLI(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// newTestModel membuat model sementara berisi beberapa pertanyaan dan
+// mengarahkan BEO_MODEL ke model tersebut
+func newTestModel(t *testing.T) string {
+	t.Helper()
+	model := filepath.Join(t.TempDir(), "model.yml")
+	t.Setenv("BEO_MODEL", model)
+
+	for _, args := range [][]string{
+		{"train", "What is your name?", "I am Beo."},
+		{"train", "Where is the office?", "Jakarta."},
+		{"train", "--tag", "support", "How do I reset my password?", "Use the reset link."},
+	} {
+		if code, _, stderr := runCLI(t, args...); code != exitOK {
+			t.Fatalf("For %q expected exit code %d, but got %d: %s", args, exitOK, code, stderr)
+		}
+	}
+	return model
+}
+
+// Test kode keluar 0 untuk sukses, 1 untuk error, dan 2 untuk penggunaan yang salah
+func TestRunExitCodes(t *testing.T) {
+	newTestModel(t)
+	missing := filepath.Join(t.TempDir(), "missing.yml")
+
+	tests := []struct {
+		args     []string
+		expected int
+	}{
+		{[]string{"ask", "what is your name"}, exitOK},
+		{[]string{"help"}, exitOK},
+		{[]string{"help", "ask"}, exitOK},
+		{[]string{"ask", "-h"}, exitOK},
+		{[]string{"lint"}, exitOK},
+		{[]string{"--model", missing, "ask", "hello"}, exitError},
+		{[]string{"rm", "42"}, exitError},
+		{[]string{}, exitUsage},
+		{[]string{"fly"}, exitUsage},
+		{[]string{"help", "fly"}, exitUsage},
+		{[]string{"--verbose", "ask", "hello"}, exitUsage},
+		{[]string{"ask"}, exitUsage},
+		{[]string{"ask", "--bogus", "hello"}, exitUsage},
+		{[]string{"search", "--k", "0", "office"}, exitUsage},
+		{[]string{"list", "extra"}, exitUsage},
+	}
+	for _, test := range tests {
+		if code, _, stderr := runCLI(t, test.args...); code != test.expected {
+			t.Errorf("For %q expected exit code %d, but got %d: %s", test.args, test.expected, code, stderr)
+		}
+	}
+}
+
+// Test flag boleh diletakkan di antara argumen posisi dan "--" mengakhiri flag
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		args       []string
+		positional []string
+		k          int
+		explain    bool
+	}{
+		{[]string{"reset", "password"}, []string{"reset", "password"}, 5, false},
+		{[]string{"--k", "3", "reset", "password"}, []string{"reset", "password"}, 3, false},
+		{[]string{"reset", "--k=2", "password", "--explain"}, []string{"reset", "password"}, 2, true},
+		{[]string{"reset", "--", "--k", "3"}, []string{"reset", "--k", "3"}, 5, false},
+		{[]string{"--explain", "--", "-1"}, []string{"-1"}, 5, true},
+		{[]string{}, nil, 5, false},
+	}
+	for _, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		k := fs.Int("k", 5, "")
+		explain := fs.Bool("explain", false, "")
+
+		positional, err := parseArgs(fs, test.args)
+		if err != nil {
+			t.Fatalf("For %q unexpected error: %v", test.args, err)
+		}
+		if !reflect.DeepEqual(positional, test.positional) || *k != test.k || *explain != test.explain {
+			t.Errorf("For %q expected %q (k=%d, explain=%v), but got %q (k=%d, explain=%v)",
+				test.args, test.positional, test.k, test.explain, positional, *k, *explain)
+		}
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	if _, err := parseArgs(fs, []string{"reset", "--unknown"}); err == nil {
+		t.Error("Expected an error for an unknown flag")
+	}
+}
+
+// Test openModel tidak membuat model baru untuk subcommand yang hanya membaca
+func TestOpenModel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.yml")
+
+	if _, err := openModel(path, false); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error, but got %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Expected read-only open not to create %s, but got %v", path, err)
+	}
+
+	file, err := openModel(path, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	file.Close()
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected writing open to create %s, but got %v", path, err)
+	}
+}
+
+// Test --model lebih diutamakan daripada BEO_MODEL
+func TestModelPrecedence(t *testing.T) {
+	envModel := newTestModel(t)
+	flagModel := filepath.Join(t.TempDir(), "flag.yml")
+
+	if code, _, stderr := runCLI(t, "--model", flagModel, "train", "Ping?", "Pong."); code != exitOK {
+		t.Fatalf("Expected exit code %d, but got %d: %s", exitOK, code, stderr)
+	}
+
+	// Tanpa --model, BEO_MODEL dipakai
+	if _, stdout, _ := runCLI(t, "ask", "what is your name"); stdout != "I am Beo.\n" {
+		t.Errorf("Expected answer from %s, but got %q", envModel, stdout)
+	}
+	// --model dapat diletakkan sebelum atau sesudah subcommand
+	for _, args := range [][]string{
+		{"--model", flagModel, "ask", "ping"},
+		{"ask", "ping", "--model", flagModel},
+	} {
+		if _, stdout, _ := runCLI(t, args...); stdout != "Pong.\n" {
+			t.Errorf("For %q expected answer from %s, but got %q", args, flagModel, stdout)
+		}
+	}
+	if _, stdout, _ := runCLI(t, "--model", flagModel, "ask", "what is your name"); stdout == "I am Beo.\n" {
+		t.Errorf("Expected --model to override BEO_MODEL, but got %q", stdout)
+	}
+}
+
+// Test rm menghapus pertanyaan berdasarkan ID dari list tanpa menggeser ID lain
+func TestRemoveByID(t *testing.T) {
+	newTestModel(t)
+
+	if code, _, stderr := runCLI(t, "rm", "1", "#3"); code != exitOK {
+		t.Fatalf("Expected exit code %d, but got %d: %s", exitOK, code, stderr)
+	}
+
+	_, stdout, _ := runCLI(t, "--json", "list")
+	var entries []struct {
+		ID       int    `json:"id"`
+		Question string `json:"question"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &entries); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", stdout, err)
+	}
+	if len(entries) != 1 || entries[0].ID != 1 || entries[0].Question != "Where is the office?" {
+		t.Errorf("Expected only \"Where is the office?\" to remain, but got %+v", entries)
+	}
+}
+
+// goFieldName mengembalikan kunci JSON pertama yang diawali huruf kapital, yaitu
+// nama field Go tanpa tag json, atau string kosong
+func goFieldName(value any) string {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if key != "" && unicode.IsUpper([]rune(key)[0]) {
+				return key
+			}
+			if key := goFieldName(item); key != "" {
+				return key
+			}
+		}
+	case []any:
+		for _, item := range value {
+			if key := goFieldName(item); key != "" {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// Test --json menghasilkan JSON yang valid untuk setiap subcommand
+func TestJSONOutput(t *testing.T) {
+	newTestModel(t)
+
+	tests := []struct {
+		args []string
+		// check memeriksa hasil decode JSON
+		check func(value any) bool
+	}{
+		{[]string{"ask", "where is the office"}, func(value any) bool {
+			response, ok := value.(map[string]any)
+			return ok && response["text"] == "Jakarta."
+		}},
+		{[]string{"ask", "--explain", "where is the ofice"}, func(value any) bool {
+			explanation, ok := value.(map[string]any)
+			return ok && explanation["input"] == "where is the ofice"
+		}},
+		{[]string{"explain", "where is the ofice"}, func(value any) bool {
+			explanation, ok := value.(map[string]any)
+			if !ok {
+				return false
+			}
+			segments, ok := explanation["segments"].([]any)
+			if !ok || len(segments) != 1 {
+				return false
+			}
+			segment := segments[0].(map[string]any)
+			corrections, ok := segment["corrections"].([]any)
+			if !ok || len(corrections) != 1 || corrections[0].(map[string]any)["corrected"] != "office" {
+				return false
+			}
+			matches, ok := segment["matches"].([]any)
+			if !ok || len(matches) != 1 {
+				return false
+			}
+			runnersUp, ok := matches[0].(map[string]any)["runners_up"].([]any)
+			if !ok || len(runnersUp) == 0 {
+				return false
+			}
+			question, ok := runnersUp[0].(map[string]any)["question"].(map[string]any)
+			return ok && question["question"] != nil
+		}},
+		{[]string{"list"}, func(value any) bool {
+			entries, ok := value.([]any)
+			return ok && len(entries) == 3
+		}},
+		{[]string{"search", "--k", "1", "reset password"}, func(value any) bool {
+			results, ok := value.([]any)
+			if !ok || len(results) != 1 {
+				return false
+			}
+			result, ok := results[0].(map[string]any)
+			return ok && result["id"] == 3.0 && result["question"] == "How do I reset my password?"
+		}},
+		{[]string{"lint"}, func(value any) bool {
+			report, ok := value.(map[string]any)
+			return ok && report["valid"] == true
+		}},
+		{[]string{"synonyms", "hello"}, func(value any) bool {
+			synonyms, ok := value.([]any)
+			return ok && len(synonyms) == 0
+		}},
+		{[]string{"placeholder", "user", "Ana"}, func(value any) bool {
+			report, ok := value.(map[string]any)
+			return ok && report["ok"] == true
+		}},
+	}
+	for _, test := range tests {
+		args := append([]string{"--json"}, test.args...)
+		code, stdout, stderr := runCLI(t, args...)
+		if code != exitOK {
+			t.Errorf("For %q expected exit code %d, but got %d: %s", args, exitOK, code, stderr)
+			continue
+		}
+		var value any
+		if err := json.Unmarshal([]byte(stdout), &value); err != nil {
+			t.Errorf("For %q expected valid JSON, but got %q: %v", args, stdout, err)
+			continue
+		}
+		if key := goFieldName(value); key != "" {
+			t.Errorf("For %q expected lowercase JSON keys, but got %q in %s", args, key, stdout)
+		}
+		if !test.check(value) {
+			t.Errorf("For %q got unexpected JSON %s", args, stdout)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package beo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Format penyusunan jawaban dari beberapa pertanyaan
+const (
+	ComposePlain    = "plain"
+	ComposeNumbered = "numbered"
+	ComposeBullets  = "bullets"
+)
+
+// Composition mengatur cara jawaban dari beberapa pertanyaan digabungkan
+type Composition struct {
+	// Dedupe menghapus jawaban untuk pertanyaan yang sama atau teks yang sama
+	Dedupe bool `yaml:"dedupe,omitempty"`
+	// Format adalah plain (bawaan), numbered, atau bullets
+	Format string `yaml:"format,omitempty"`
+	// Separator adalah pemisah antar jawaban, bawaan spasi untuk plain dan baris baru untuk daftar
+	Separator string `yaml:"separator,omitempty"`
+	// Template adalah text/template untuk menyusun teks akhir dari .Answers, menggantikan Format
+	Template string `yaml:"template,omitempty"`
+	// MaxAnswers adalah jumlah maksimum jawaban, 0 berarti tanpa batas
+	MaxAnswers int `yaml:"maxanswers,omitempty"`
+}
+
+// Answer adalah jawaban untuk satu pertanyaan yang ditemukan dalam input
+type Answer struct {
+	// ID adalah nomor pertanyaan yang cocok, yaitu urutannya dalam knowledge base mulai dari 1
+	ID int `json:"id"`
+	// Segment adalah urutan kalimat input tempat pertanyaan ditemukan, mulai dari 0
+	Segment int `json:"segment"`
+	// Input adalah teks kalimat input tersebut
+	Input string `json:"input"`
+	// Question adalah pertanyaan yang cocok dalam knowledge base
+	Question string `json:"question"`
+	// Text adalah jawaban yang sudah dirender
+	Text string `json:"text"`
+	// Score adalah skor kemiripan antara 0 dan 1
+	Score float64 `json:"score"`
+	// Fallback adalah jenis fallback jika jawaban berasal dari fallback
+	Fallback string `json:"fallback,omitempty"`
+	// Clarification bernilai true jika Text adalah pesan klarifikasi
+	Clarification bool `json:"clarification,omitempty"`
+}
+
+// CompositionData adalah data yang tersedia bagi template komposisi
+type CompositionData struct {
+	Answers []Answer
+}
+
+// separator mengembalikan pemisah antar jawaban sesuai format
+func (c Composition) separator() string {
+	if c.Separator != "" {
+		return c.Separator
+	}
+	if c.Format == ComposeNumbered || c.Format == ComposeBullets {
+		return "\n"
+	}
+	return " "
+}
+
+// parseTemplate mem-parsing template komposisi, atau nil jika tidak ada template
+func (c Composition) parseTemplate() (*template.Template, error) {
+	if c.Template == "" {
+		return nil, nil
+	}
+	return template.New("composition").Funcs(templateFuncs).Option("missingkey=zero").Parse(c.Template)
+}
+
+// validate memeriksa format dan template komposisi
+func (c Composition) validate() error {
+	switch c.Format {
+	case "", ComposePlain, ComposeNumbered, ComposeBullets:
+	default:
+		return fmt.Errorf("unknown composition format %q", c.Format)
+	}
+	if c.MaxAnswers < 0 {
+		return fmt.Errorf("composition maxanswers %d must not be negative", c.MaxAnswers)
+	}
+	if _, err := c.parseTemplate(); err != nil {
+		return fmt.Errorf("invalid composition template: %w", err)
+	}
+	return nil
+}
+
+// SetComposition mengganti konfigurasi penyusunan jawaban
+func (ai *AI) SetComposition(composition Composition) error {
+	if err := composition.validate(); err != nil {
+		return err
+	}
+	ai.KnowledgeBase.Composition = composition
+	return nil
+}
+
+// compose menyusun teks akhir dari daftar jawaban
+func (c Composition) compose(answers []Answer) (string, error) {
+	tmpl, err := c.parseTemplate()
+	if err != nil {
+		return "", fmt.Errorf("gagal mem-parsing template komposisi: %w", err)
+	}
+	if tmpl != nil {
+		var builder strings.Builder
+		if err := tmpl.Execute(&builder, CompositionData{Answers: answers}); err != nil {
+			return "", fmt.Errorf("gagal menjalankan template komposisi: %w", err)
+		}
+		return builder.String(), nil
+	}
+
+	texts := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		if answer.Text == "" {
+			continue
+		}
+		switch c.Format {
+		case ComposeNumbered:
+			texts = append(texts, strconv.Itoa(len(texts)+1)+". "+answer.Text)
+		case ComposeBullets:
+			texts = append(texts, "- "+answer.Text)
+		default:
+			texts = append(texts, answer.Text)
+		}
+	}
+	return strings.Join(texts, c.separator()), nil
+}
+
+// accept mengecek apakah jawaban masih boleh ditambahkan sesuai Dedupe dan MaxAnswers
+func (c Composition) accept(answers []Answer, answer Answer) bool {
+	if c.MaxAnswers > 0 && len(answers) >= c.MaxAnswers {
+		return false
+	}
+	if !c.Dedupe {
+		return true
+	}
+	for _, existing := range answers {
+		if existing.Text == answer.Text || (answer.Question != "" && existing.Question == answer.Question && !answer.Clarification) {
+			return false
+		}
+	}
+	return true
+}
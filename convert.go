@@ -0,0 +1,651 @@
+package beo
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConversionIssue adalah bagian dari korpus asal yang tidak dapat dikonversi
+// sepenuhnya
+type ConversionIssue struct {
+	// Location menunjukkan asal konstruksi, misalnya `category 3 "HELLO *"`
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+// ConversionReport meringkas hasil konversi korpus chatbot lain
+type ConversionReport struct {
+	// Converted adalah jumlah pertanyaan yang dihasilkan
+	Converted int `json:"converted"`
+	// Skipped adalah jumlah entri asal yang tidak menghasilkan pertanyaan
+	Skipped int               `json:"skipped"`
+	Issues  []ConversionIssue `json:"issues,omitempty"`
+}
+
+// String menampilkan ringkasan singkat laporan konversi
+func (r ConversionReport) String() string {
+	return fmt.Sprintf("%d converted, %d skipped, %d issues", r.Converted, r.Skipped, len(r.Issues))
+}
+
+// issue mencatat konstruksi yang tidak dapat dikonversi
+func (r *ConversionReport) issue(location, format string, args ...any) {
+	r.Issues = append(r.Issues, ConversionIssue{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// conversion mengumpulkan pertanyaan hasil konversi dan menggabungkan
+// pertanyaan yang sama
+type conversion struct {
+	kb     KnowledgeBase
+	report ConversionReport
+}
+
+// add menambahkan pertanyaan, atau menggabungkannya jika teksnya sudah ada
+func (c *conversion) add(q Question) {
+	if i := findQuestion(c.kb.Questions, q.Question); i >= 0 {
+		c.kb.Questions[i], _ = mergeQuestion(c.kb.Questions[i], q)
+		return
+	}
+	c.kb.Questions = append(c.kb.Questions, q)
+}
+
+// result mengembalikan knowledge base dan laporan konversi
+func (c *conversion) result() (KnowledgeBase, ConversionReport) {
+	c.report.Converted = len(c.kb.Questions)
+	return c.kb, c.report
+}
+
+// ConvertAIML mengonversi file kategori AIML. Setiap <pattern> menjadi
+// pertanyaan dan <template> menjadi jawaban; <random> menjadi beberapa
+// jawaban atau variasi {a|b}, dan <topic> menjadi tag. Template yang hanya
+// berisi <srai> dipetakan ke hook bersama dengan kategori tujuannya. Wildcard
+// pada pola dibuang, dan elemen yang bergantung pada keadaan percakapan
+// (<star/>, <get>, <set>, <that>, <condition>, ...) dilaporkan.
+func ConvertAIML(r io.Reader) (KnowledgeBase, ConversionReport, error) {
+	root, err := parseXML(r)
+	if err != nil {
+		return KnowledgeBase{}, ConversionReport{}, fmt.Errorf("failed to parse AIML: %w", err)
+	}
+	aiml := root.child("aiml")
+	if aiml == nil {
+		return KnowledgeBase{}, ConversionReport{}, errors.New("failed to parse AIML: missing <aiml> root element")
+	}
+
+	var categories []aimlCategory
+	for _, node := range aiml.children {
+		switch node.name {
+		case "category":
+			categories = append(categories, aimlCategory{node: node, number: len(categories) + 1})
+		case "topic":
+			topic := strings.ToLower(strings.TrimSpace(node.attr("name")))
+			for _, category := range node.children {
+				if category.name == "category" {
+					categories = append(categories, aimlCategory{node: category, number: len(categories) + 1, topic: topic})
+				}
+			}
+		}
+	}
+
+	c := &conversion{kb: KnowledgeBase{Hooks: make(map[string]Hook)}}
+	for i := range categories {
+		categories[i].parse(&c.report)
+	}
+
+	// Kategori dengan pola yang sama persis dicari melalui kuncinya
+	byPattern := make(map[string]*aimlCategory)
+	for i := range categories {
+		if categories[i].key != "" && byPattern[categories[i].key] == nil {
+			byPattern[categories[i].key] = &categories[i]
+		}
+	}
+	for i := range categories {
+		category := &categories[i]
+		if category.srai == "" || category.question == "" {
+			continue
+		}
+		target := resolveSrai(category, byPattern)
+		if target == nil {
+			c.report.issue(category.location(), "srai target %q was not found or has no fixed answers", category.srai)
+			category.question = ""
+			continue
+		}
+		if target.hook == "" {
+			target.hook = aimlHookName(target.key, c.kb.Hooks)
+			c.kb.Hooks[target.hook] = Hook{Answers: target.answers}
+		}
+		category.hook = target.hook
+	}
+
+	for _, category := range categories {
+		if category.question == "" {
+			c.report.Skipped++
+			continue
+		}
+		q := Question{Question: category.question, Hook: category.hook}
+		if category.hook == "" {
+			q.Answers = category.answers
+		}
+		if category.topic != "" && category.topic != "*" {
+			q.Tags = []string{category.topic}
+		}
+		c.add(q)
+	}
+	kb, report := c.result()
+	return kb, report, nil
+}
+
+// aimlCategory adalah satu <category> AIML beserta hasil konversinya
+type aimlCategory struct {
+	node   *xmlNode
+	number int
+	topic  string
+
+	pattern  string
+	key      string
+	question string
+	answers  []string
+	srai     string
+	hook     string
+}
+
+// location menunjukkan kategori dalam laporan konversi
+func (c *aimlCategory) location() string {
+	return fmt.Sprintf("category %d %q", c.number, c.pattern)
+}
+
+// parse mengonversi pola dan template kategori
+func (c *aimlCategory) parse(report *ConversionReport) {
+	pattern := c.node.child("pattern")
+	if pattern == nil {
+		report.issue(c.location(), "category has no <pattern>")
+		return
+	}
+	for _, element := range pattern.elements() {
+		report.issue(c.location(), "<%s> in a pattern is not supported and was removed", element.name)
+	}
+	c.pattern = strings.Join(strings.Fields(pattern.text()), " ")
+	c.key = strings.ToUpper(c.pattern)
+
+	var words []string
+	for _, word := range strings.Fields(c.pattern) {
+		if strings.ContainsAny(word, "*_^#") {
+			continue
+		}
+		words = append(words, word)
+	}
+	if len(words) < len(strings.Fields(c.pattern)) {
+		report.issue(c.location(), "wildcards cannot be matched and were removed from the pattern")
+	}
+	if len(words) == 0 {
+		report.issue(c.location(), "pattern has no words left after removing wildcards")
+		return
+	}
+	if that := c.node.child("that"); that != nil {
+		report.issue(c.location(), "<that> context %q is ignored", strings.TrimSpace(that.text()))
+	}
+
+	template := c.node.child("template")
+	if template == nil {
+		report.issue(c.location(), "category has no <template>")
+		return
+	}
+
+	// Template yang hanya berisi <srai> menjawab seperti kategori tujuannya
+	if content := template.content(); len(content) == 1 && content[0].name == "srai" {
+		srai := content[0]
+		if len(srai.elements()) > 0 {
+			report.issue(c.location(), "<srai> with <%s> cannot be resolved", srai.elements()[0].name)
+			return
+		}
+		c.srai = strings.ToUpper(strings.Join(strings.Fields(srai.text()), " "))
+		c.question = aimlQuestion(words)
+		return
+	}
+
+	// Template yang hanya berisi <random> menjadi beberapa jawaban
+	var answers []string
+	if content := template.content(); len(content) == 1 && content[0].name == "random" {
+		for _, item := range content[0].children {
+			if item.name == "li" {
+				answers = append(answers, renderAIML(item, c, report))
+			}
+		}
+	} else {
+		answers = []string{renderAIML(template, c, report)}
+	}
+
+	for _, answer := range answers {
+		if answer = strings.TrimSpace(answer); answer != "" && !contains(c.answers, answer) {
+			c.answers = append(c.answers, answer)
+		}
+	}
+	if len(c.answers) == 0 {
+		report.issue(c.location(), "template has no text left after conversion")
+		return
+	}
+	c.question = aimlQuestion(words)
+}
+
+// resolveSrai mengikuti rantai <srai> hingga kategori yang memiliki jawaban
+func resolveSrai(category *aimlCategory, byPattern map[string]*aimlCategory) *aimlCategory {
+	seen := map[*aimlCategory]bool{category: true}
+	current := category
+	for current.srai != "" {
+		next := byPattern[current.srai]
+		if next == nil || seen[next] || next.question == "" {
+			return nil
+		}
+		seen[next] = true
+		current = next
+	}
+	return current
+}
+
+// aimlSpace mencocokkan spasi dan baris baru pada teks AIML
+var aimlSpace = regexp.MustCompile(`\s+`)
+
+// renderAIML mengubah isi elemen template AIML menjadi teks jawaban Beo
+func renderAIML(node *xmlNode, category *aimlCategory, report *ConversionReport) string {
+	var b strings.Builder
+	for _, child := range node.children {
+		switch child.name {
+		case "":
+			// Hanya <br/> yang menjadi baris baru pada jawaban
+			b.WriteString(aimlSpace.ReplaceAllString(child.data, " "))
+		case "br":
+			b.WriteString("\n")
+		case "date":
+			b.WriteString("%date%")
+		case "bot":
+			name := strings.ToLower(child.attr("name"))
+			if name == "name" {
+				b.WriteString("%ainame%")
+			} else {
+				b.WriteString("%" + name + "%")
+				report.issue(category.location(), "bot property %q became placeholder %%%s%%; add it with AddPlaceholder", name, name)
+			}
+		case "random":
+			var items []string
+			for _, item := range child.children {
+				if item.name == "li" {
+					items = append(items, strings.TrimSpace(renderAIML(item, category, report)))
+				}
+			}
+			b.WriteString("{" + strings.Join(items, "|") + "}")
+		case "uppercase":
+			b.WriteString(strings.ToUpper(renderAIML(child, category, report)))
+		case "lowercase":
+			b.WriteString(strings.ToLower(renderAIML(child, category, report)))
+		default:
+			report.issue(category.location(), "<%s> is not supported and was removed", child.name)
+		}
+	}
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// aimlQuestion mengubah kata-kata pola AIML menjadi kalimat pertanyaan
+func aimlQuestion(words []string) string {
+	return capitalize(strings.ToLower(strings.Join(words, " ")))
+}
+
+// aimlHookName membuat nama hook yang belum dipakai dari pola AIML
+func aimlHookName(pattern string, hooks map[string]Hook) string {
+	var words []string
+	for _, word := range tokenize(pattern) {
+		if !strings.ContainsAny(word, "*_^#") {
+			words = append(words, word)
+		}
+	}
+	base := strings.Join(words, "_")
+	if base == "" {
+		base = "srai"
+	}
+	name := base
+	for i := 2; ; i++ {
+		if _, exists := hooks[name]; !exists {
+			return name
+		}
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+}
+
+// capitalize mengubah huruf pertama teks menjadi huruf besar
+func capitalize(text string) string {
+	r, size := utf8.DecodeRuneInString(text)
+	if r == utf8.RuneError {
+		return text
+	}
+	return string(unicode.ToUpper(r)) + text[size:]
+}
+
+// xmlNode adalah elemen XML dengan isi campuran. Node teks memiliki name kosong.
+type xmlNode struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlNode
+	data     string
+}
+
+// parseXML membaca seluruh dokumen menjadi pohon xmlNode
+func parseXML(r io.Reader) (*xmlNode, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return root, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parent := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: strings.ToLower(t.Name.Local), attrs: t.Attr}
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			parent.children = append(parent.children, &xmlNode{data: string(t)})
+		}
+	}
+}
+
+// child mengembalikan elemen anak pertama dengan nama tertentu
+func (n *xmlNode) child(name string) *xmlNode {
+	for _, child := range n.children {
+		if child.name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// elements mengembalikan seluruh elemen anak tanpa node teks
+func (n *xmlNode) elements() []*xmlNode {
+	var elements []*xmlNode
+	for _, child := range n.children {
+		if child.name != "" {
+			elements = append(elements, child)
+		}
+	}
+	return elements
+}
+
+// content mengembalikan anak-anak node tanpa node teks yang hanya berisi spasi
+func (n *xmlNode) content() []*xmlNode {
+	var content []*xmlNode
+	for _, child := range n.children {
+		if child.name != "" || strings.TrimSpace(child.data) != "" {
+			content = append(content, child)
+		}
+	}
+	return content
+}
+
+// text menggabungkan seluruh teks langsung di dalam node
+func (n *xmlNode) text() string {
+	var b strings.Builder
+	for _, child := range n.children {
+		if child.name == "" {
+			b.WriteString(child.data)
+		}
+	}
+	return b.String()
+}
+
+// attr mengembalikan nilai atribut, atau string kosong
+func (n *xmlNode) attr(name string) string {
+	for _, attr := range n.attrs {
+		if strings.EqualFold(attr.Name.Local, name) {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// chatterBotCorpus adalah file korpus YAML ChatterBot
+type chatterBotCorpus struct {
+	Categories    []string `yaml:"categories"`
+	Conversations [][]any  `yaml:"conversations"`
+}
+
+// ConvertChatterBot mengonversi korpus percakapan YAML ChatterBot. Setiap
+// pernyataan menjadi pertanyaan dan pernyataan berikutnya menjadi jawabannya.
+// Kategori korpus menjadi tag. Percakapan lebih dari dua giliran dilaporkan
+// karena giliran berikutnya dijawab tanpa konteks.
+func ConvertChatterBot(r io.Reader) (KnowledgeBase, ConversionReport, error) {
+	var corpus chatterBotCorpus
+	if err := yaml.NewDecoder(r).Decode(&corpus); err != nil {
+		return KnowledgeBase{}, ConversionReport{}, fmt.Errorf("failed to parse ChatterBot corpus: %w", err)
+	}
+
+	var tags []string
+	for _, category := range corpus.Categories {
+		if tag := strings.ToLower(strings.TrimSpace(category)); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	c := &conversion{}
+	for i, conversation := range corpus.Conversations {
+		location := fmt.Sprintf("conversation %d", i+1)
+		if len(conversation) < 2 {
+			c.report.issue(location, "conversation has no response")
+			c.report.Skipped++
+			continue
+		}
+		if len(conversation) > 2 {
+			c.report.issue(location, "conversation has %d turns; turns after the first are answered without context", len(conversation))
+		}
+
+		converted := false
+		for j := 0; j+1 < len(conversation); j++ {
+			question, ok := conversation[j].(string)
+			answer, ok2 := conversation[j+1].(string)
+			if !ok || !ok2 {
+				c.report.issue(location, "turn %d is not plain text", j+1)
+				continue
+			}
+			if question, answer = strings.TrimSpace(question), strings.TrimSpace(answer); question == "" || answer == "" {
+				continue
+			}
+			c.add(Question{Question: question, Answers: []string{answer}, Tags: tags})
+			converted = true
+		}
+		if !converted {
+			c.report.Skipped++
+		}
+	}
+	kb, report := c.result()
+	return kb, report, nil
+}
+
+// rasaFile adalah bagian file training data dan domain Rasa yang dikonversi
+type rasaFile struct {
+	NLU       []map[string]any            `yaml:"nlu"`
+	Responses map[string][]map[string]any `yaml:"responses"`
+	Rules     []rasaFlow                  `yaml:"rules"`
+	Stories   []rasaFlow                  `yaml:"stories"`
+}
+
+// rasaFlow adalah satu rule atau story Rasa
+type rasaFlow struct {
+	Rule  string           `yaml:"rule"`
+	Story string           `yaml:"story"`
+	Steps []map[string]any `yaml:"steps"`
+}
+
+// rasaEntity mencocokkan anotasi entitas seperti [Jakarta](city) atau [Jakarta]{"entity": "city"}
+var rasaEntity = regexp.MustCompile(`\[([^\]]+)\](\([^)]*\)|\{[^}]*\})`)
+
+// ConvertRasa mengonversi training data Rasa (YAML) menjadi knowledge base.
+// Setiap intent menjadi satu pertanyaan: contoh pertama menjadi teks
+// pertanyaan dan contoh lainnya menjadi alias. Jawaban diambil dari response
+// yang dipicu intent tersebut pada rules atau stories, atau dari response
+// bernama utter_<intent>. Beberapa file, misalnya nlu.yml, domain.yml, dan
+// rules.yml, dapat diberikan sekaligus. Synonym menjadi grup sinonim.
+func ConvertRasa(files ...io.Reader) (KnowledgeBase, ConversionReport, error) {
+	var data rasaFile
+	data.Responses = make(map[string][]map[string]any)
+	for i, r := range files {
+		var file rasaFile
+		if err := yaml.NewDecoder(r).Decode(&file); err != nil && err != io.EOF {
+			return KnowledgeBase{}, ConversionReport{}, fmt.Errorf("failed to parse Rasa file %d: %w", i+1, err)
+		}
+		data.NLU = append(data.NLU, file.NLU...)
+		for name, response := range file.Responses {
+			data.Responses[name] = response
+		}
+		data.Rules = append(data.Rules, file.Rules...)
+		data.Stories = append(data.Stories, file.Stories...)
+	}
+
+	c := &conversion{}
+
+	// Intent dipetakan ke action pertama yang mengikutinya pada rules dan stories
+	actions := make(map[string]string)
+	for _, flow := range append(data.Rules, data.Stories...) {
+		for i := 0; i+1 < len(flow.Steps); i++ {
+			intent, _ := flow.Steps[i]["intent"].(string)
+			action, _ := flow.Steps[i+1]["action"].(string)
+			if intent != "" && action != "" && actions[intent] == "" {
+				actions[intent] = action
+			}
+		}
+	}
+
+	for i, item := range data.NLU {
+		location := fmt.Sprintf("nlu item %d", i+1)
+		examples := rasaExamples(item["examples"])
+		switch {
+		case item["intent"] != nil:
+			intent := fmt.Sprint(item["intent"])
+			location = fmt.Sprintf("intent %q", intent)
+			c.convertRasaIntent(location, intent, examples, actions, data.Responses)
+		case item["synonym"] != nil:
+			group := []string{fmt.Sprint(item["synonym"])}
+			for _, example := range examples {
+				group = append(group, example)
+			}
+			c.kb.Synonyms = append(c.kb.Synonyms, group)
+		default:
+			kinds := make([]string, 0, len(item))
+			for key := range item {
+				if key != "examples" {
+					kinds = append(kinds, key)
+				}
+			}
+			sort.Strings(kinds)
+			c.report.issue(location, "%s is not supported", strings.Join(kinds, ", "))
+			c.report.Skipped++
+		}
+	}
+	kb, report := c.result()
+	return kb, report, nil
+}
+
+// convertRasaIntent mengonversi satu intent Rasa menjadi pertanyaan dengan alias
+func (c *conversion) convertRasaIntent(location, intent string, examples []string, actions map[string]string, responses map[string][]map[string]any) {
+	var texts []string
+	annotated := false
+	for _, example := range examples {
+		if rasaEntity.MatchString(example) {
+			example = rasaEntity.ReplaceAllString(example, "$1")
+			annotated = true
+		}
+		if example = strings.TrimSpace(example); example != "" && !contains(texts, example) {
+			texts = append(texts, example)
+		}
+	}
+	if len(texts) == 0 {
+		c.report.issue(location, "intent has no examples")
+		c.report.Skipped++
+		return
+	}
+	if annotated {
+		c.report.issue(location, "entity annotations were removed from the examples")
+	}
+
+	action := actions[intent]
+	if action == "" {
+		action = "utter_" + intent
+	}
+	q := Question{Question: texts[0]}
+	if len(texts) > 1 {
+		q.Aliases = texts[1:]
+	}
+	response, ok := responses[action]
+	if !ok {
+		if actions[intent] != "" {
+			c.report.issue(location, "intent triggers custom action %q; add a hook named %q", action, intent)
+		} else {
+			c.report.issue(location, "intent has no response; add a hook named %q", intent)
+		}
+		q.Hook = intent
+		c.add(q)
+		return
+	}
+
+	var unsupported []string
+	for _, variant := range response {
+		text, _ := variant["text"].(string)
+		if text != "" && !contains(q.Answers, text) {
+			q.Answers = append(q.Answers, text)
+		}
+		for key := range variant {
+			if key != "text" && !contains(unsupported, key) {
+				unsupported = append(unsupported, key)
+			}
+		}
+	}
+	sort.Strings(unsupported)
+	for _, key := range unsupported {
+		c.report.issue(location, "%q in response %q is not supported", key, action)
+	}
+	for _, answer := range q.Answers {
+		if strings.Contains(answer, "{") {
+			c.report.issue(location, "slot variables in response %q are not filled", action)
+			break
+		}
+	}
+	if len(q.Answers) == 0 {
+		c.report.issue(location, "response %q has no text; add a hook named %q", action, intent)
+		q.Hook = intent
+	}
+	c.add(q)
+}
+
+// rasaExamples memecah blok contoh Rasa ("- contoh" per baris) menjadi daftar
+func rasaExamples(value any) []string {
+	text, _ := value.(string)
+	var examples []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if example := strings.TrimSpace(strings.TrimPrefix(line, "-")); line != "" && example != "" {
+			examples = append(examples, example)
+		}
+	}
+	return examples
+}
@@ -1,34 +1,55 @@
 package beo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
-	"strings"
+	"sort"
+	"text/template"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Struktur utama AI
 type AI struct {
-	KnowledgeBase KnowledgeBase
-	file          *os.File
+	KnowledgeBase    KnowledgeBase
+	file             *os.File
+	hookFuncs        map[string]HookFunc
+	placeholderFuncs map[string]PlaceholderFunc
 }
 
 // KnowledgeBase merepresentasikan database pertanyaan dan jawaban
 type KnowledgeBase struct {
-	AIName       string            `yaml:"name"`
-	Model        string            `yaml:"model"`
-	Trainer      string            `yaml:"trainer"`
-	Fallbacks    Fallbacks         `yaml:"fallbacks"`
-	Formats      Formats           `yaml:"formats"`
-	Placeholders map[string]string `yaml:"placeholders"`
-	Questions    []Question        `yaml:"questions"`
-	Hooks        map[string]Hook   `yaml:"hooks"`
+	AIName        string            `yaml:"name"`
+	Model         string            `yaml:"model"`
+	Trainer       string            `yaml:"trainer"`
+	Render        string            `yaml:"render,omitempty"`
+	Pipeline      []string          `yaml:"pipeline,omitempty"`
+	Stemmer       string            `yaml:"stemmer,omitempty"`
+	Stopwords     Stopwords         `yaml:"stopwords,omitempty"`
+	Synonyms      [][]string        `yaml:"synonyms,omitempty"`
+	Matching      Matching          `yaml:"matching,omitempty"`
+	Clarification Clarification     `yaml:"clarification,omitempty"`
+	Segmentation  Segmentation      `yaml:"segmentation,omitempty"`
+	Composition   Composition       `yaml:"composition,omitempty"`
+	Dictionary    []string          `yaml:"dictionary,omitempty"`
+	Fallbacks     Fallbacks         `yaml:"fallbacks"`
+	Formats       Formats           `yaml:"formats"`
+	Placeholders  map[string]string `yaml:"placeholders"`
+	Questions     []Question        `yaml:"questions"`
+	Hooks         map[string]Hook   `yaml:"hooks"`
 
 	IDF        map[string]float64 `yaml:"-"`
+	CharIDF    map[string]float64 `yaml:"-"`
 	Corpus     [][]string         `yaml:"-"`
 	Vocabulary []string           `yaml:"-"`
+	Frequency  map[string]int     `yaml:"-"`
+
+	templates       map[string]*template.Template
+	filters         []TextFilter
+	vocabularyIndex *spellIndex
+	questionVectors *questionVectors
 }
 
 // Formats merepresentasikan struktur format placeholder
@@ -40,14 +61,34 @@ type Formats struct {
 
 // Fallbacks merepresentasikan struktur fallback untuk berbagai kondisi
 type Fallbacks struct {
-	NoAnswer string `yaml:"noanswer"`
+	NoAnswer      string              `yaml:"noanswer"`
+	NoAnswers     []string            `yaml:"noanswers,omitempty"`
+	LowConfidence []string            `yaml:"lowconfidence,omitempty"`
+	Partial       []string            `yaml:"partial,omitempty"`
+	EmptyInput    []string            `yaml:"emptyinput,omitempty"`
+	TooLong       []string            `yaml:"toolong,omitempty"`
+	Tags          map[string][]string `yaml:"tags,omitempty"`
+
+	// Threshold adalah skor minimum agar jawaban dianggap yakin, 0 menonaktifkan fallback low-confidence
+	Threshold float64 `yaml:"threshold,omitempty"`
+	// MaxLength adalah panjang input maksimum dalam karakter, 0 berarti tanpa batas
+	MaxLength int `yaml:"maxlength,omitempty"`
 }
 
 // Question merepresentasikan sebuah pertanyaan dan jawaban
 type Question struct {
-	Question string   `yaml:"question"`
-	Answers  []string `yaml:"answers,omitempty"`
-	Hook     string   `yaml:"hook,omitempty"`
+	Question string `yaml:"question" json:"question"`
+	// Aliases adalah cara lain menanyakan pertanyaan yang sama. Input yang
+	// cocok dengan alias dijawab seperti input yang cocok dengan Question.
+	Aliases []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	Answers []string `yaml:"answers,omitempty" json:"answers,omitempty"`
+	Hook    string   `yaml:"hook,omitempty" json:"hook,omitempty"`
+	Tags    []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// texts mengembalikan teks pertanyaan diikuti seluruh aliasnya
+func (q Question) texts() []string {
+	return append([]string{q.Question}, q.Aliases...)
 }
 
 // Hook merepresentasikan hook yang memiliki jawaban
@@ -118,14 +159,16 @@ func (ai *AI) load() error {
 			TimeZone: "UTC",
 		}
 	}
-	if (kb.Fallbacks == Fallbacks{}) {
-		kb.Fallbacks = Fallbacks{
-			NoAnswer: "I'm sorry, I don't know the answer to that.",
-		}
+	if kb.Fallbacks.NoAnswer == "" && len(kb.Fallbacks.NoAnswers) == 0 {
+		kb.Fallbacks.NoAnswer = "I'm sorry, I don't know the answer to that."
 	}
 
+	if err := kb.updatePipeline(); err != nil {
+		return err
+	}
 	kb.updateIDF()
 	kb.updateVocabularies()
+	kb.updateTemplates()
 	ai.KnowledgeBase = kb
 	return nil
 }
@@ -151,37 +194,208 @@ func (ai *AI) Save() error {
 
 // Mencari jawaban terbaik berdasarkan pertanyaan
 func (ai *AI) Ask(question string) string {
-	var bestMatches []Question
-	var answers []string
+	answer, err := ai.AskContext(context.Background(), question)
+	if err != nil {
+		return randomChoice(ai.KnowledgeBase.Fallbacks.noAnswers())
+	}
+	return answer
+}
+
+// AskContext sama seperti Ask, tetapi dapat dibatalkan melalui ctx.
+// Pencocokan, hook, dan placeholder berhenti segera setelah ctx dibatalkan
+// atau melewati batas waktu, lalu error dari ctx dikembalikan.
+func (ai *AI) AskContext(ctx context.Context, question string) (string, error) {
+	response, err := ai.Respond(ctx, question)
+	if err != nil {
+		return "", err
+	}
+	return response.Text, nil
+}
+
+// Respond sama seperti AskContext, tetapi mengembalikan Response lengkap
+// beserta koreksi typo yang dilakukan pada input
+func (ai *AI) Respond(ctx context.Context, question string) (Response, error) {
+	var response Response
+	var bestMatches []match
+	var answers []Answer
+	composition := ai.KnowledgeBase.Composition
+	fallbacks := ai.KnowledgeBase.Fallbacks
+	session := sessionFromContext(ctx)
+
+	// Input kosong atau terlalu panjang tidak dicocokkan
+	if len(tokenize(question)) == 0 {
+		return ai.fallbackResponse(ctx, response, FallbackEmptyInput, nil)
+	}
+	if fallbacks.MaxLength > 0 && len([]rune(question)) > fallbacks.MaxLength {
+		return ai.fallbackResponse(ctx, response, FallbackTooLong, nil)
+	}
+
+	// Jawaban lanjutan atas klarifikasi sebelumnya ("the second one")
+	if session != nil {
+		if candidates := session.takeCandidates(); len(candidates) > 0 {
+			if chosen, ok := chooseCandidate(question, candidates); ok {
+				if index := ai.KnowledgeBase.findQuestion(chosen); index >= 0 {
+					bestMatches = append(bestMatches, match{question: ai.KnowledgeBase.Questions[index], index: index, score: 1, input: question})
+				}
+			}
+		}
+	}
+
+	// Pencocokan dilewati jika input adalah pilihan klarifikasi
+	followUp := len(bestMatches) > 0
+
+	unmatchedSegments := 0
+	segments := ai.KnowledgeBase.segment(question)
+	for index, segment := range segments {
+		if followUp {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return Response{}, err
+		}
 
-	segments := splitByPunctuation(question)
-	for _, segment := range segments {
 		// Tokenisasi dan koreksi typo
-		inputTokens := tokenize(segment)
-		correctedTokens := correctInput(inputTokens, ai.KnowledgeBase.Vocabulary)
+		inputTokens := ai.KnowledgeBase.analyze(segment)
+		correctedTokens, corrections := correctInput(inputTokens, ai.KnowledgeBase.index())
+		response.Corrections = append(response.Corrections, corrections...)
 
 		// Cari pola yang cocok
-		bestMatches = append(bestMatches, findBestMatches(correctedTokens, ai.KnowledgeBase)...)
+		matches, err := findBestMatches(ctx, correctedTokens, ai.KnowledgeBase)
+		if err != nil {
+			return Response{}, err
+		}
+		if len(inputTokens) > 0 && len(matches) == 0 {
+			unmatchedSegments++
+		}
+		for _, m := range matches {
+			m.segment, m.input = index, segment
+			bestMatches = append(bestMatches, m)
+		}
 	}
+	response.Corrected = applyCorrections(question, response.Corrections)
 
+	answered := 0
 	for _, bestMatch := range bestMatches {
-		if bestMatch.Hook != "" {
-			hook, ok := ai.KnowledgeBase.Hooks[bestMatch.Hook]
-			if ok {
-				answers = append(answers, randomChoice(hook.Answers))
+		answer := Answer{
+			ID:       bestMatch.index + 1,
+			Segment:  bestMatch.segment,
+			Input:    bestMatch.input,
+			Question: bestMatch.question.Question,
+			Score:    bestMatch.score,
+		}
+		// Pertanyaan yang sama tidak dijawab dua kali
+		if !composition.accept(answers, answer) {
+			continue
+		}
+
+		// Tanyakan balik jika beberapa pertanyaan hampir sama cocoknya
+		if candidates := ai.KnowledgeBase.clarificationCandidates(bestMatch); len(candidates) > 1 {
+			clarification, err := ai.renderClarification(ctx, question, candidates)
+			if err != nil {
+				return Response{}, err
+			}
+			answer.Text, answer.Clarification = clarification, true
+			response.Candidates = response.Candidates[:0]
+			for _, candidate := range candidates {
+				response.Candidates = append(response.Candidates, candidate.Question)
+			}
+			if session != nil {
+				session.setCandidates(response.Candidates)
+			}
+			answers = append(answers, answer)
+			continue
+		}
+
+		// Skor di bawah ambang batas lunak memakai fallback low-confidence
+		if bestMatch.score < fallbacks.Threshold {
+			text, err := ai.renderFallback(ctx, FallbackLowConfidence, bestMatch.question.Tags, Slots{"question": bestMatch.question.Question})
+			if err != nil {
+				return Response{}, err
+			}
+			answer.Text, answer.Fallback = text, FallbackLowConfidence
+			response.Fallback = FallbackLowConfidence
+			if composition.accept(answers, answer) {
+				answers = append(answers, answer)
+			}
+			continue
+		}
+
+		text, ok, err := ai.answerQuestion(ctx, bestMatch.question, question)
+		if err != nil {
+			return Response{}, err
+		}
+		answer.Text = text
+		if ok && composition.accept(answers, answer) {
+			answers = append(answers, answer)
+			answered++
+			if session != nil {
+				session.setTopic(bestMatch.question.Tags)
 			}
-		} else {
-			answers = append(answers, randomChoice(bestMatch.Answers))
 		}
 	}
 
 	// Gunakan fallback untuk jawaban default
 	if len(bestMatches) < 1 {
-		return ai.KnowledgeBase.Fallbacks.NoAnswer
+		var topic []string
+		if session != nil {
+			topic = session.topic()
+		}
+		return ai.fallbackResponse(ctx, response, FallbackNoAnswer, topic)
+	}
+
+	text, err := composition.compose(answers)
+	if err != nil {
+		return Response{}, err
+	}
+	response.Answers = answers
+
+	// Sebagian pertanyaan tidak terjawab
+	if answered > 0 && unmatchedSegments > 0 {
+		partial, err := ai.renderFallback(ctx, FallbackPartial, nil, nil)
+		if err != nil {
+			return Response{}, err
+		}
+		if partial != "" {
+			response.Fallback = FallbackPartial
+			text += composition.separator() + partial
+		}
 	}
 
-	// Mengganti placeholders
-	return processPlaceholders(strings.Join(answers, " "), ai.KnowledgeBase)
+	response.Text = text
+	return response, nil
+}
+
+// fallbackResponse mengisi response dengan jawaban fallback jenis kind
+func (ai *AI) fallbackResponse(ctx context.Context, response Response, kind string, tags []string) (Response, error) {
+	text, err := ai.renderFallback(ctx, kind, tags, nil)
+	if err != nil {
+		return Response{}, err
+	}
+	response.Text = text
+	response.Fallback = kind
+	return response, nil
+}
+
+// answerQuestion memilih dan merender jawaban untuk pertanyaan yang cocok.
+// false dikembalikan jika hook pertanyaan tidak memiliki jawaban.
+func (ai *AI) answerQuestion(ctx context.Context, bestMatch Question, input string) (string, bool, error) {
+	var answer string
+	if bestMatch.Hook != "" {
+		hookAnswer, ok, err := ai.resolveHook(ctx, bestMatch.Hook, input)
+		if err != nil || !ok {
+			return "", false, err
+		}
+		answer = hookAnswer
+	} else {
+		answer = randomChoice(bestMatch.Answers)
+	}
+
+	// Mengganti placeholders atau menjalankan template
+	rendered, err := ai.renderAnswer(ctx, answer, input, bestMatch)
+	if err != nil {
+		return "", false, err
+	}
+	return rendered, true, nil
 }
 
 // Melatih AI dengan pertanyaan, jawaban, atau hook
@@ -196,6 +410,7 @@ func (ai *AI) Train(question string, answers []string, hook string) {
 			}
 			ai.KnowledgeBase.updateIDF()
 			ai.KnowledgeBase.updateVocabularies()
+			ai.KnowledgeBase.updateTemplates()
 			return
 		}
 	}
@@ -208,6 +423,21 @@ func (ai *AI) Train(question string, answers []string, hook string) {
 
 	ai.KnowledgeBase.updateIDF()
 	ai.KnowledgeBase.updateVocabularies()
+	ai.KnowledgeBase.updateTemplates()
+}
+
+// RemoveQuestion menghapus pertanyaan beserta jawabannya dari knowledge base
+func (ai *AI) RemoveQuestion(question string) error {
+	for i, q := range ai.KnowledgeBase.Questions {
+		if q.Question == question {
+			ai.KnowledgeBase.Questions = append(ai.KnowledgeBase.Questions[:i], ai.KnowledgeBase.Questions[i+1:]...)
+			ai.KnowledgeBase.updateIDF()
+			ai.KnowledgeBase.updateVocabularies()
+			ai.KnowledgeBase.updateTemplates()
+			return nil
+		}
+	}
+	return fmt.Errorf("question %q not found", question)
 }
 
 // Menambahkan hook baru
@@ -216,6 +446,17 @@ func (ai *AI) AddHook(hookName string, answers []string) {
 		ai.KnowledgeBase.Hooks = make(map[string]Hook)
 	}
 	ai.KnowledgeBase.Hooks[hookName] = Hook{Answers: answers}
+	ai.KnowledgeBase.updateTemplates()
+}
+
+// AddHookFunc mendaftarkan hook dinamis yang jawabannya dihasilkan oleh fungsi.
+// Hook dinamis tidak disimpan ke file dan didahulukan daripada hook statis
+// dengan nama yang sama.
+func (ai *AI) AddHookFunc(hookName string, fn HookFunc) {
+	if ai.hookFuncs == nil {
+		ai.hookFuncs = make(map[string]HookFunc)
+	}
+	ai.hookFuncs[hookName] = fn
 }
 
 // Menambahkan placeholder baru
@@ -226,31 +467,77 @@ func (ai *AI) AddPlaceholder(key, value string) {
 	ai.KnowledgeBase.Placeholders[key] = value
 }
 
-// updateIDF menghitung dan memperbarui nilai Inverse Document Frequency (IDF) di dalam KnowledgeBase.
+// updateIDF menghitung dan memperbarui nilai Inverse Document Frequency (IDF) di dalam KnowledgeBase,
+// lalu menghitung ulang vektor TF-IDF pertanyaan yang dipakai saat pencocokan.
+// Setiap pertanyaan menjadi satu dokumen yang berisi token pertanyaan dan seluruh
+// aliasnya, sehingga kata yang dipakai di banyak alias satu pertanyaan tidak
+// kehilangan bobot.
 func (kb *KnowledgeBase) updateIDF() {
-	corpus := [][]string{}
-	for _, question := range kb.Questions {
-		corpus = append(corpus, tokenize(question.Question))
+	corpus := make([][]string, len(kb.Questions))
+	charCorpus := make([][]string, len(kb.Questions))
+	for i, question := range kb.Questions {
+		for _, text := range question.texts() {
+			tokens := kb.analyze(text)
+			corpus[i] = append(corpus[i], tokens...)
+			if kb.Matching.NGram > 0 {
+				// N-gram dihitung per teks agar tidak melintasi batas alias
+				charCorpus[i] = append(charCorpus[i], charNGrams(tokens, kb.Matching.NGram)...)
+			}
+		}
 	}
 
 	kb.Corpus = corpus
 	kb.IDF = inverseDocumentFrequency(corpus)
+
+	kb.CharIDF = nil
+	if kb.Matching.NGram > 0 {
+		kb.CharIDF = inverseDocumentFrequency(charCorpus)
+	}
+
+	kb.questionVectors = kb.newQuestionVectors()
 }
 
 // updateVocabularies memperbarui daftar kosakata (Vocabulary) di dalam KnowledgeBase.
+// Frekuensi setiap kata juga dihitung agar koreksi typo mengutamakan kata yang sering muncul.
 func (kb *KnowledgeBase) updateVocabularies() {
-	uniqueVocabularies := map[string]bool{}
+	frequency := map[string]int{}
 
 	for _, question := range kb.Questions {
-		for _, word := range tokenize(question.Question) {
-			uniqueVocabularies[word] = true
+		for _, text := range question.texts() {
+			for _, word := range kb.analyze(text) {
+				frequency[word]++
+			}
 		}
 	}
 
 	var vocabularyList []string
-	for word := range uniqueVocabularies {
+	for word := range frequency {
 		vocabularyList = append(vocabularyList, word)
 	}
+	sort.Strings(vocabularyList)
 
 	kb.Vocabulary = vocabularyList
+	kb.Frequency = frequency
+	kb.vocabularyIndex = kb.newSpellIndex()
+}
+
+// newSpellIndex membangun indeks koreksi typo dari kosakata dan kamus pengguna
+func (kb *KnowledgeBase) newSpellIndex() *spellIndex {
+	index := newSpellIndex(kb.Vocabulary, maxCorrectionDistance, kb.Matching.distanceFunc())
+	index.frequency = kb.Frequency
+	for _, word := range kb.Dictionary {
+		for _, token := range kb.analyze(word) {
+			index.protected[token] = true
+		}
+	}
+	return index
+}
+
+// index mengembalikan indeks kosakata untuk koreksi typo. Jika kosakata diubah
+// langsung tanpa updateVocabularies, indeks dibangun ulang dari Vocabulary.
+func (kb *KnowledgeBase) index() *spellIndex {
+	if kb.vocabularyIndex == nil || len(kb.vocabularyIndex.words) != len(kb.Vocabulary) {
+		return kb.newSpellIndex()
+	}
+	return kb.vocabularyIndex
 }
@@ -1,24 +1,243 @@
 package beo
 
-// Koreksi kata berdasarkan Levenshtein Distance
-func correctWord(word string, vocabulary []string) string {
-	minDistance := 5
-	corrected := word
-	for _, vocabWord := range vocabulary {
-		distance := levenshtein(word, vocabWord)
-		if distance < minDistance && distance <= 2 {
-			minDistance = distance
-			corrected = vocabWord
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxCorrectionDistance adalah jarak edit maksimum untuk mengoreksi typo
+const maxCorrectionDistance = 2
+
+// Correction mencatat sebuah kata input yang dikoreksi
+type Correction struct {
+	Original   string  `json:"original"`
+	Corrected  string  `json:"corrected"`
+	Distance   float64 `json:"distance"`
+	Confidence float64 `json:"confidence"`
+}
+
+// SetDictionary mengganti kamus pengguna, yaitu kata yang tidak boleh dikoreksi
+// meskipun tidak ada di kosakata (nama produk, istilah, singkatan)
+func (ai *AI) SetDictionary(words ...string) error {
+	previous := ai.KnowledgeBase.Dictionary
+	ai.KnowledgeBase.Dictionary = words
+	if err := ai.reindex(); err != nil {
+		ai.KnowledgeBase.Dictionary = previous
+		return err
+	}
+	return nil
+}
+
+// allowedDistance mengembalikan jarak edit maksimum berdasarkan panjang kata.
+// Kata pendek tidak dikoreksi agar kata valid seperti "is" tidak berubah menjadi "it".
+func allowedDistance(word string) float64 {
+	switch length := len([]rune(word)); {
+	case length <= 2:
+		return 0
+	case length <= 5:
+		return 1
+	}
+	return maxCorrectionDistance
+}
+
+// spellIndex adalah indeks koreksi typo dengan pendekatan SymSpell. Setiap kata
+// kosakata disimpan bersama seluruh variasinya setelah menghapus hingga
+// maxDistance huruf. Dua kata yang berjarak edit paling banyak maxDistance
+// selalu memiliki variasi hapus yang sama, sehingga kandidat koreksi cukup
+// dicari dari variasi hapus input tanpa membandingkan seluruh kosakata.
+//
+// Pertukaran huruf juga tercakup karena cukup satu penghapusan di setiap sisi.
+// Kandidat kemudian diukur dengan fungsi jarak yang dikonfigurasi. Karena
+// variasi hapus hanya mencakup maxDistance edit biasa, bobot keyboard hanya
+// mengurutkan ulang kandidat dalam jarak tersebut: tiga penggantian huruf
+// bersebelahan (jarak 1.5) tidak ditemukan. Menambah kedalaman hapus menjadi
+// maxDistance/adjacentKeyCost akan membuat indeks beberapa kali lebih besar,
+// padahal indeks dibangun ulang setiap kali pertanyaan dilatih.
+type spellIndex struct {
+	maxDistance int
+	distance    func(a, b string) float64
+	words       map[string]bool
+	deletes     map[string][]string
+	frequency   map[string]int
+	protected   map[string]bool
+}
+
+// spellMatch adalah kandidat koreksi beserta jaraknya
+type spellMatch struct {
+	word     string
+	distance float64
+}
+
+// newSpellIndex membangun indeks koreksi dari daftar kata
+func newSpellIndex(words []string, maxDistance int, distance func(a, b string) float64) *spellIndex {
+	index := &spellIndex{
+		maxDistance: maxDistance,
+		distance:    distance,
+		words:       make(map[string]bool, len(words)),
+		deletes:     make(map[string][]string),
+		protected:   make(map[string]bool),
+	}
+	for _, word := range words {
+		if index.words[word] {
+			continue
+		}
+		index.words[word] = true
+		for variant := range deleteVariants(word, maxDistance) {
+			index.deletes[variant] = append(index.deletes[variant], word)
+		}
+	}
+	return index
+}
+
+// deleteVariants menghasilkan kata itu sendiri dan seluruh variasinya
+// setelah menghapus hingga maxDistance huruf
+func deleteVariants(word string, maxDistance int) map[string]bool {
+	variants := map[string]bool{word: true}
+	current := []string{word}
+	for distance := 0; distance < maxDistance; distance++ {
+		var next []string
+		for _, variant := range current {
+			letters := []rune(variant)
+			if len(letters) <= 1 {
+				continue
+			}
+			for i := range letters {
+				deleted := string(letters[:i]) + string(letters[i+1:])
+				if !variants[deleted] {
+					variants[deleted] = true
+					next = append(next, deleted)
+				}
+			}
+		}
+		current = next
+	}
+	return variants
+}
+
+// lookup mencari seluruh kata kosakata dengan jarak edit paling banyak maxDistance.
+// Hanya kata yang berjarak paling banyak s.maxDistance edit biasa yang diperiksa,
+// berapa pun jarak berbobot yang diizinkan.
+func (s *spellIndex) lookup(word string, maxDistance float64) []spellMatch {
+	if s == nil {
+		return nil
+	}
+	if s.words[word] {
+		return []spellMatch{{word, 0}}
+	}
+
+	seen := map[string]bool{}
+	var matches []spellMatch
+	for variant := range deleteVariants(word, s.maxDistance) {
+		for _, candidate := range s.deletes[variant] {
+			if seen[candidate] {
+				continue
+			}
+			seen[candidate] = true
+			if distance := s.distance(word, candidate); distance <= maxDistance {
+				matches = append(matches, spellMatch{candidate, distance})
+			}
 		}
 	}
-	return corrected
+	return matches
 }
 
-// Koreksi seluruh input
-func correctInput(input []string, vocabulary []string) []string {
+// Koreksi kata berdasarkan jarak edit menggunakan indeks kosakata.
+// Kata yang sudah ada di kosakata atau kamus pengguna tidak dikoreksi.
+// Kata terdekat dipilih; jika jaraknya sama dipilih kata yang paling sering
+// muncul, lalu urutan abjad terkecil.
+func correctWord(word string, index *spellIndex) (string, *Correction) {
+	if index == nil || index.words[word] || index.protected[word] {
+		return word, nil
+	}
+
+	matches := index.lookup(word, allowedDistance(word))
+	if len(matches) == 0 {
+		return word, nil
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		if fi, fj := index.frequency[matches[i].word], index.frequency[matches[j].word]; fi != fj {
+			return fi > fj
+		}
+		return matches[i].word < matches[j].word
+	})
+
+	best := matches[0]
+	return best.word, &Correction{
+		Original:   word,
+		Corrected:  best.word,
+		Distance:   best.distance,
+		Confidence: correctionConfidence(word, best, matches[1:]),
+	}
+}
+
+// correctionConfidence memperkirakan keyakinan koreksi antara 0 dan 1.
+// Keyakinan turun seiring jarak edit relatif terhadap panjang kata, dan
+// dibagi jika ada kandidat lain dengan jarak yang sama.
+func correctionConfidence(word string, best spellMatch, others []spellMatch) float64 {
+	confidence := 1 - best.distance/float64(len([]rune(word)))
+	ties := 1
+	for _, other := range others {
+		if other.distance == best.distance {
+			ties++
+		}
+	}
+	return math.Max(0, confidence) / float64(ties)
+}
+
+// Koreksi seluruh input dan laporkan kata yang dikoreksi
+func correctInput(input []string, index *spellIndex) ([]string, []Correction) {
 	corrected := []string{}
+	var corrections []Correction
 	for _, word := range input {
-		corrected = append(corrected, correctWord(word, vocabulary))
+		correctedWord, correction := correctWord(word, index)
+		corrected = append(corrected, correctedWord)
+		if correction != nil {
+			corrections = append(corrections, *correction)
+		}
+	}
+	return corrected, corrections
+}
+
+// applyCorrections menulis ulang input dengan kata yang sudah dikoreksi untuk
+// ditampilkan sebagai "menampilkan hasil untuk ...". Kata dibandingkan setelah
+// normalisasi, dan string kosong dikembalikan jika tidak ada koreksi.
+func applyCorrections(input string, corrections []Correction) string {
+	if len(corrections) == 0 {
+		return ""
+	}
+
+	replacements := make(map[string]string, len(corrections))
+	for _, correction := range corrections {
+		replacements[correction.Original] = correction.Corrected
+	}
+
+	words := strings.Fields(input)
+	for i, word := range words {
+		tokens := tokenize(word)
+		if len(tokens) != 1 {
+			continue
+		}
+		if replacement, ok := replacements[tokens[0]]; ok {
+			start, end := wordBounds(word)
+			words[i] = word[:start] + replacement + word[end:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// wordBounds mengembalikan posisi awal dan akhir bagian kata tanpa tanda baca di sekitarnya
+func wordBounds(word string) (int, int) {
+	start := strings.IndexFunc(word, isWordRune)
+	end := strings.LastIndexFunc(word, isWordRune)
+	if start < 0 {
+		return 0, len(word)
 	}
-	return corrected
+	_, size := utf8.DecodeRuneInString(word[end:])
+	return start, end + size
 }
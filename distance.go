@@ -0,0 +1,107 @@
+package beo
+
+// Jenis jarak edit yang dapat dipilih pada konfigurasi pencocokan
+const (
+	DistanceLevenshtein = "levenshtein"
+	DistanceDamerau     = "damerau"
+)
+
+// adjacentKeyCost adalah biaya penggantian huruf yang bersebelahan pada keyboard QWERTY
+const adjacentKeyCost = 0.5
+
+// qwertyAdjacency memetakan setiap huruf ke huruf yang bersebelahan pada keyboard QWERTY
+var qwertyAdjacency = buildKeyboardAdjacency([]string{"qwertyuiop", "asdfghjkl", "zxcvbnm"})
+
+// buildKeyboardAdjacency menyusun peta huruf bersebelahan dari baris keyboard.
+// Baris keyboard bergeser ke kanan, sehingga huruf ke-i bersebelahan dengan
+// huruf ke-i dan ke-i+1 pada baris di atasnya, serta ke-i-1 dan ke-i di bawahnya.
+func buildKeyboardAdjacency(rows []string) map[rune]map[rune]bool {
+	adjacency := make(map[rune]map[rune]bool)
+	link := func(a rune, row, i int) {
+		if row < 0 || row >= len(rows) || i < 0 || i >= len(rows[row]) {
+			return
+		}
+		b := rune(rows[row][i])
+		if adjacency[a] == nil {
+			adjacency[a] = make(map[rune]bool)
+		}
+		adjacency[a][b] = true
+	}
+
+	for row, keys := range rows {
+		for i, key := range keys {
+			link(key, row, i-1)
+			link(key, row, i+1)
+			link(key, row-1, i)
+			link(key, row-1, i+1)
+			link(key, row+1, i-1)
+			link(key, row+1, i)
+		}
+	}
+	return adjacency
+}
+
+// editDistance menghitung jarak edit berbobot antara dua string per rune.
+// Jika transpositions aktif, pertukaran dua huruf bersebelahan ("teh" dan "the")
+// dihitung sebagai satu operasi (Damerau-Levenshtein, optimal string alignment).
+// Jika keyboard aktif, penggantian huruf yang bersebelahan pada keyboard QWERTY
+// hanya berbiaya setengah.
+func editDistance(s1, s2 string, transpositions, keyboard bool) float64 {
+	a, b := []rune(s1), []rune(s2)
+	n, m := len(a), len(b)
+	if n == 0 {
+		return float64(m)
+	}
+	if m == 0 {
+		return float64(n)
+	}
+
+	d := make([][]float64, n+1)
+	for i := range d {
+		d[i] = make([]float64, m+1)
+		d[i][0] = float64(i)
+	}
+	for j := 0; j <= m; j++ {
+		d[0][j] = float64(j)
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost := 0.0
+			if a[i-1] != b[j-1] {
+				cost = 1
+				if keyboard && qwertyAdjacency[a[i-1]][b[j-1]] {
+					cost = adjacentKeyCost
+				}
+			}
+
+			best := d[i-1][j] + 1
+			if insert := d[i][j-1] + 1; insert < best {
+				best = insert
+			}
+			if substitute := d[i-1][j-1] + cost; substitute < best {
+				best = substitute
+			}
+			if transpositions && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				if transpose := d[i-2][j-2] + 1; transpose < best {
+					best = transpose
+				}
+			}
+			d[i][j] = best
+		}
+	}
+	return d[n][m]
+}
+
+// distanceFunc mengembalikan fungsi jarak edit sesuai konfigurasi pencocokan
+func (m Matching) distanceFunc() func(a, b string) float64 {
+	transpositions := m.Distance == DistanceDamerau
+	if !transpositions && !m.Keyboard {
+		return func(a, b string) float64 {
+			return float64(levenshtein(a, b))
+		}
+	}
+	return func(a, b string) float64 {
+		return editDistance(a, b, transpositions, m.Keyboard)
+	}
+}
@@ -0,0 +1,265 @@
+package beo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Explanation menjelaskan langkah-langkah yang dilakukan Ask untuk sebuah input
+type Explanation struct {
+	Input    string               `json:"input"`
+	Segments []SegmentExplanation `json:"segments"`
+	// Substitutions berisi placeholder yang diganti saat jawaban dirender
+	Substitutions []Substitution `json:"substitutions,omitempty"`
+	// Response adalah hasil akhir, sama seperti hasil Respond
+	Response Response `json:"response"`
+}
+
+// SegmentExplanation menjelaskan pencocokan satu kalimat input
+type SegmentExplanation struct {
+	Text string `json:"text"`
+	// Tokens adalah token setelah pipeline, sebelum koreksi typo
+	Tokens []string `json:"tokens"`
+	// Corrected adalah token setelah koreksi typo
+	Corrected   []string     `json:"corrected"`
+	Corrections []Correction `json:"corrections,omitempty"`
+	// Windows adalah seluruh rentang token yang dicoba oleh pencocokan
+	Windows []Window           `json:"windows"`
+	Matches []MatchExplanation `json:"matches"`
+}
+
+// Window adalah satu rentang token yang dicoba beserta pertanyaan terbaiknya
+type Window struct {
+	Start    int      `json:"start"`
+	End      int      `json:"end"`
+	Tokens   []string `json:"tokens"`
+	Question string   `json:"question,omitempty"`
+	Score    float64  `json:"score"`
+}
+
+// MatchExplanation menjelaskan mengapa sebuah pertanyaan terpilih
+type MatchExplanation struct {
+	Question string `json:"question"`
+	// Alias adalah alias yang paling cocok, kosong jika teks pertanyaan sendiri yang cocok
+	Alias string  `json:"alias,omitempty"`
+	Start int     `json:"start"`
+	End   int     `json:"end"`
+	Score float64 `json:"score"`
+	// WordScore dan CharScore adalah skor TF-IDF kata dan n-gram karakter
+	WordScore float64 `json:"word_score"`
+	CharScore float64 `json:"char_score"`
+	// Terms adalah kontribusi setiap kata terhadap WordScore, dari yang terbesar
+	Terms []TermContribution `json:"terms"`
+	// RunnersUp adalah pertanyaan lain dengan skor tertinggi pada rentang yang sama
+	RunnersUp []SearchResult `json:"runners_up,omitempty"`
+}
+
+// TermContribution adalah bobot TF-IDF sebuah kata pada input dan pertanyaan
+// serta sumbangannya terhadap kemiripan kosinus
+type TermContribution struct {
+	Term         string  `json:"term"`
+	Input        float64 `json:"input"`
+	Question     float64 `json:"question"`
+	Contribution float64 `json:"contribution"`
+}
+
+// Substitution mencatat satu placeholder dan nilai penggantinya
+type Substitution struct {
+	Placeholder string `json:"placeholder"`
+	Value       string `json:"value"`
+}
+
+// maxRunnersUp adalah jumlah pertanyaan pembanding yang ditampilkan
+const maxRunnersUp = 3
+
+// explainTrace mengumpulkan jejak pencocokan dan placeholder selama Explain
+type explainTrace struct {
+	windows       []Window
+	substitutions []Substitution
+}
+
+type explainKey struct{}
+
+// traceFromContext mengambil jejak Explain yang dibawa oleh ctx, atau nil
+func traceFromContext(ctx context.Context) *explainTrace {
+	trace, _ := ctx.Value(explainKey{}).(*explainTrace)
+	return trace
+}
+
+// Explain menjalankan pertanyaan seperti Respond dan mencatat setiap langkahnya:
+// pemecahan kalimat, token sebelum dan sesudah koreksi typo, rentang token yang
+// dicoba, kontribusi TF-IDF pertanyaan pemenang, pembanding, dan placeholder.
+// Sesi pada ctx disalin sehingga Explain tidak mengubah percakapan.
+func (ai *AI) Explain(ctx context.Context, input string) (Explanation, error) {
+	kb := &ai.KnowledgeBase
+	explanation := Explanation{Input: input}
+	if session := sessionFromContext(ctx); session != nil {
+		ctx = WithSession(ctx, session.clone())
+	}
+
+	scorer := newScorer(kb)
+	for _, segment := range kb.segment(input) {
+		trace := &explainTrace{}
+		tokens := kb.analyze(segment)
+		corrected, corrections := correctInput(tokens, kb.index())
+
+		matches, err := findBestMatches(context.WithValue(ctx, explainKey{}, trace), corrected, *kb)
+		if err != nil {
+			return Explanation{}, err
+		}
+
+		segmentExplanation := SegmentExplanation{
+			Text:        segment,
+			Tokens:      tokens,
+			Corrected:   corrected,
+			Corrections: corrections,
+			Windows:     trace.windows,
+		}
+		for _, m := range matches {
+			segmentExplanation.Matches = append(segmentExplanation.Matches, scorer.explainMatch(m, corrected))
+		}
+		explanation.Segments = append(explanation.Segments, segmentExplanation)
+	}
+
+	trace := &explainTrace{}
+	response, err := ai.Respond(context.WithValue(ctx, explainKey{}, trace), input)
+	if err != nil {
+		return Explanation{}, err
+	}
+	explanation.Response = response
+	explanation.Substitutions = trace.substitutions
+	return explanation, nil
+}
+
+// explainMatch menghitung rincian skor pertanyaan yang cocok pada rentang tokennya
+func (s *scorer) explainMatch(m match, tokens []string) MatchExplanation {
+	words, chars := s.inputVectors(tokens[m.start:m.end])
+	explanation := MatchExplanation{
+		Question: m.question.Question,
+		Start:    m.start,
+		End:      m.end,
+		Score:    m.score,
+	}
+
+	for i, question := range s.kb.Questions {
+		if i == m.index {
+			continue
+		}
+		if score := s.similarity(words, chars, i); score > 0 {
+			explanation.RunnersUp = append(explanation.RunnersUp, SearchResult{Question: question, Score: score})
+		}
+	}
+	sort.SliceStable(explanation.RunnersUp, func(i, j int) bool {
+		return explanation.RunnersUp[i].Score > explanation.RunnersUp[j].Score
+	})
+	if len(explanation.RunnersUp) > maxRunnersUp {
+		explanation.RunnersUp = explanation.RunnersUp[:maxRunnersUp]
+	}
+
+	text, _ := s.bestText(words, chars, m.index)
+	if text > 0 {
+		explanation.Alias = m.question.Aliases[text-1]
+	}
+	explanation.WordScore = cosineSimilarity(words, s.words[m.index][text])
+	if s.ngram > 0 {
+		explanation.CharScore = cosineSimilarity(chars, s.chars[m.index][text])
+	}
+	explanation.Terms = termContributions(words, s.words[m.index][text])
+	return explanation
+}
+
+// termContributions menguraikan kemiripan kosinus menjadi sumbangan setiap kata
+// yang ada di kedua vektor
+func termContributions(input, question map[string]float64) []TermContribution {
+	magnitude := math.Sqrt(sumSquares(input)) * math.Sqrt(sumSquares(question))
+	if magnitude == 0 {
+		return nil
+	}
+
+	var terms []TermContribution
+	for term, weight := range input {
+		if questionWeight, exists := question[term]; exists {
+			terms = append(terms, TermContribution{
+				Term:         term,
+				Input:        weight,
+				Question:     questionWeight,
+				Contribution: weight * questionWeight / magnitude,
+			})
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Contribution != terms[j].Contribution {
+			return terms[i].Contribution > terms[j].Contribution
+		}
+		return terms[i].Term < terms[j].Term
+	})
+	return terms
+}
+
+// sumSquares menjumlahkan kuadrat setiap bobot vektor
+func sumSquares(vector map[string]float64) float64 {
+	sum := 0.0
+	for _, value := range vector {
+		sum += value * value
+	}
+	return sum
+}
+
+// String menghasilkan laporan Explain yang mudah dibaca
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Input: %q\n", e.Input)
+
+	for i, segment := range e.Segments {
+		fmt.Fprintf(&b, "\nSegment %d: %q\n", i+1, segment.Text)
+		fmt.Fprintf(&b, "  Tokens:    %s\n", strings.Join(segment.Tokens, " "))
+		fmt.Fprintf(&b, "  Corrected: %s\n", strings.Join(segment.Corrected, " "))
+		for _, correction := range segment.Corrections {
+			fmt.Fprintf(&b, "    %s -> %s (distance %.1f, confidence %.2f)\n",
+				correction.Original, correction.Corrected, correction.Distance, correction.Confidence)
+		}
+
+		fmt.Fprintf(&b, "  Windows:\n")
+		for _, window := range segment.Windows {
+			question := window.Question
+			if question == "" {
+				question = "-"
+			}
+			fmt.Fprintf(&b, "    [%d:%d] %-30s %.3f %s\n", window.Start, window.End,
+				strings.Join(window.Tokens, " "), window.Score, question)
+		}
+
+		if len(segment.Matches) == 0 {
+			fmt.Fprintf(&b, "  No match\n")
+		}
+		for _, m := range segment.Matches {
+			fmt.Fprintf(&b, "  Match [%d:%d]: %q score %.3f (words %.3f, chars %.3f)\n",
+				m.Start, m.End, m.Question, m.Score, m.WordScore, m.CharScore)
+			if m.Alias != "" {
+				fmt.Fprintf(&b, "    via alias %q\n", m.Alias)
+			}
+			for _, term := range m.Terms {
+				fmt.Fprintf(&b, "    %-20s input %.3f  question %.3f  contribution %.3f\n",
+					term.Term, term.Input, term.Question, term.Contribution)
+			}
+			for _, runnerUp := range m.RunnersUp {
+				fmt.Fprintf(&b, "    runner-up %.3f %s\n", runnerUp.Score, runnerUp.Question.Question)
+			}
+		}
+	}
+
+	if len(e.Substitutions) > 0 {
+		fmt.Fprintf(&b, "\nPlaceholders:\n")
+		for _, substitution := range e.Substitutions {
+			fmt.Fprintf(&b, "  %s -> %q\n", substitution.Placeholder, substitution.Value)
+		}
+	}
+	if e.Response.Fallback != "" {
+		fmt.Fprintf(&b, "\nFallback: %s\n", e.Response.Fallback)
+	}
+	fmt.Fprintf(&b, "\nAnswer: %s\n", e.Response.Text)
+	return b.String()
+}
@@ -0,0 +1,109 @@
+package beo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Jenis fallback yang dilaporkan pada Response.Fallback
+const (
+	FallbackNoAnswer      = "noanswer"
+	FallbackLowConfidence = "lowconfidence"
+	FallbackPartial       = "partial"
+	FallbackEmptyInput    = "emptyinput"
+	FallbackTooLong       = "toolong"
+)
+
+// noAnswers mengembalikan seluruh jawaban fallback NoAnswer
+func (f Fallbacks) noAnswers() []string {
+	var answers []string
+	if f.NoAnswer != "" {
+		answers = append(answers, f.NoAnswer)
+	}
+	return append(answers, f.NoAnswers...)
+}
+
+// answers mengembalikan pilihan jawaban untuk jenis fallback. Fallback per tag
+// didahulukan untuk NoAnswer dan LowConfidence, dan jenis lain yang tidak
+// dikonfigurasi memakai NoAnswer, kecuali Partial yang kemudian tidak ditambahkan.
+func (f Fallbacks) answers(kind string, tags []string) []string {
+	if kind == FallbackNoAnswer || kind == FallbackLowConfidence {
+		for _, tag := range tags {
+			if answers := f.Tags[tag]; len(answers) > 0 {
+				return answers
+			}
+		}
+	}
+
+	var answers []string
+	switch kind {
+	case FallbackLowConfidence:
+		answers = f.LowConfidence
+	case FallbackPartial:
+		return f.Partial
+	case FallbackEmptyInput:
+		answers = f.EmptyInput
+	case FallbackTooLong:
+		answers = f.TooLong
+	}
+	if len(answers) == 0 {
+		return f.noAnswers()
+	}
+	return answers
+}
+
+// renderFallback memilih salah satu jawaban fallback secara acak lalu memproses
+// variasi dan placeholder-nya. Untuk fallback low-confidence, %question% berisi
+// pertanyaan yang paling mendekati.
+func (ai *AI) renderFallback(ctx context.Context, kind string, tags []string, slots Slots) (string, error) {
+	answer := randomChoice(ai.KnowledgeBase.Fallbacks.answers(kind, tags))
+	if answer == "" {
+		return "", nil
+	}
+	if len(slots) > 0 {
+		ctx = WithSlots(ctx, slots)
+	}
+	return ai.processPlaceholders(ctx, expandVariations(answer))
+}
+
+// SetTags mengganti tag sebuah pertanyaan yang sudah dilatih. Tag dipakai untuk
+// memilih fallback per topik.
+func (ai *AI) SetTags(question string, tags ...string) error {
+	for i, q := range ai.KnowledgeBase.Questions {
+		if q.Question == question {
+			ai.KnowledgeBase.Questions[i].Tags = tags
+			return nil
+		}
+	}
+	return fmt.Errorf("question %q not found", question)
+}
+
+// validateFallbacks memeriksa ambang batas dan tag fallback
+func (kb *KnowledgeBase) validateFallbacks() []error {
+	var errs []error
+	if kb.Fallbacks.Threshold < 0 || kb.Fallbacks.Threshold > 1 {
+		errs = append(errs, fmt.Errorf("fallback threshold %v must be between 0 and 1", kb.Fallbacks.Threshold))
+	}
+	if kb.Fallbacks.MaxLength < 0 {
+		errs = append(errs, fmt.Errorf("fallback maxlength %d must not be negative", kb.Fallbacks.MaxLength))
+	}
+
+	used := map[string]bool{}
+	for _, question := range kb.Questions {
+		for _, tag := range question.Tags {
+			used[tag] = true
+		}
+	}
+	tags := make([]string, 0, len(kb.Fallbacks.Tags))
+	for tag := range kb.Fallbacks.Tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		if !used[tag] {
+			errs = append(errs, fmt.Errorf("fallback tag %q is not used by any question", tag))
+		}
+	}
+	return errs
+}
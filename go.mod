@@ -2,4 +2,11 @@ module github.com/Ismananda/beo
 
 go 1.23.3
 
-require gopkg.in/yaml.v3 v3.0.1
+require (
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/term v0.27.0
+	golang.org/x/text v0.21.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.28.0 // indirect
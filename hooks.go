@@ -0,0 +1,53 @@
+package beo
+
+import (
+	"context"
+	"fmt"
+)
+
+// HookFunc menghasilkan jawaban hook secara dinamis berdasarkan input pengguna.
+// Fungsi sebaiknya menghormati pembatalan ctx.
+type HookFunc func(ctx context.Context, input string) (string, error)
+
+// resolveHook mencari jawaban untuk hook, mendahulukan hook dinamis.
+// Nilai bool bernilai false jika hook tidak ditemukan.
+func (ai *AI) resolveHook(ctx context.Context, name, input string) (string, bool, error) {
+	if fn, ok := ai.hookFuncs[name]; ok {
+		answer, err := callHook(ctx, fn, input)
+		if err != nil {
+			return "", false, fmt.Errorf("hook %q gagal: %w", name, err)
+		}
+		return answer, true, nil
+	}
+
+	hook, ok := ai.KnowledgeBase.Hooks[name]
+	if !ok {
+		return "", false, nil
+	}
+	return randomChoice(hook.Answers), true, nil
+}
+
+// callHook menjalankan hook dinamis dan berhenti menunggu begitu ctx dibatalkan,
+// meskipun fungsi hook sendiri tidak memeriksa ctx.
+func callHook(ctx context.Context, fn HookFunc, input string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	type result struct {
+		answer string
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		answer, err := fn(ctx, input)
+		done <- result{answer, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case r := <-done:
+		return r.answer, r.err
+	}
+}
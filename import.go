@@ -0,0 +1,324 @@
+package beo
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Mode impor
+const (
+	// ImportMerge menambahkan pertanyaan baru dan menggabungkan jawaban serta tag
+	// ke pertanyaan yang sudah ada
+	ImportMerge = "merge"
+	// ImportReplace mengganti seluruh pertanyaan dengan hasil impor
+	ImportReplace = "replace"
+)
+
+// ImportReport meringkas perubahan yang dibuat (atau akan dibuat) oleh Import
+type ImportReport struct {
+	// Added adalah pertanyaan baru
+	Added []string `json:"added,omitempty"`
+	// Updated adalah pertanyaan lama yang mendapat alias, jawaban, tag, atau hook baru
+	Updated []string `json:"updated,omitempty"`
+	// Unchanged adalah pertanyaan yang sudah sama persis
+	Unchanged []string `json:"unchanged,omitempty"`
+	// Removed adalah pertanyaan lama yang dihapus oleh ImportReplace
+	Removed []string `json:"removed,omitempty"`
+}
+
+// String menampilkan ringkasan singkat laporan impor
+func (r ImportReport) String() string {
+	return fmt.Sprintf("%d added, %d updated, %d unchanged, %d removed",
+		len(r.Added), len(r.Updated), len(r.Unchanged), len(r.Removed))
+}
+
+// Import memasukkan banyak pertanyaan sekaligus dan mengindeks ulang knowledge
+// base satu kali. Jika dryRun bernilai true, knowledge base tidak diubah dan
+// hanya laporannya yang dikembalikan. Import tidak menyimpan model; panggil
+// Save setelahnya.
+func (ai *AI) Import(questions []Question, mode string, dryRun bool) (ImportReport, error) {
+	var report ImportReport
+	if mode != ImportMerge && mode != ImportReplace {
+		return report, fmt.Errorf("unknown import mode %q (expected %q or %q)", mode, ImportMerge, ImportReplace)
+	}
+	for i, q := range questions {
+		if strings.TrimSpace(q.Question) == "" {
+			return report, fmt.Errorf("question %d is empty", i+1)
+		}
+		if len(q.Answers) == 0 && q.Hook == "" {
+			return report, fmt.Errorf("question %q has no answers or hook", q.Question)
+		}
+	}
+
+	// Pertanyaan yang sama dalam satu impor digabung menjadi satu
+	var imported []Question
+	for _, q := range questions {
+		if i := findQuestion(imported, q.Question); i >= 0 {
+			imported[i], _ = mergeQuestion(imported[i], q)
+		} else {
+			imported = append(imported, q)
+		}
+	}
+
+	existing := ai.KnowledgeBase.Questions
+	var result []Question
+	switch mode {
+	case ImportMerge:
+		result = append([]Question(nil), existing...)
+		for _, q := range imported {
+			i := findQuestion(result, q.Question)
+			if i < 0 {
+				result = append(result, q)
+				report.Added = append(report.Added, q.Question)
+				continue
+			}
+			var changed bool
+			if result[i], changed = mergeQuestion(result[i], q); changed {
+				report.Updated = append(report.Updated, q.Question)
+			} else {
+				report.Unchanged = append(report.Unchanged, q.Question)
+			}
+		}
+
+	case ImportReplace:
+		result = imported
+		for _, q := range imported {
+			i := findQuestion(existing, q.Question)
+			switch {
+			case i < 0:
+				report.Added = append(report.Added, q.Question)
+			case sameQuestion(existing[i], q):
+				report.Unchanged = append(report.Unchanged, q.Question)
+			default:
+				report.Updated = append(report.Updated, q.Question)
+			}
+		}
+		for _, q := range existing {
+			if findQuestion(imported, q.Question) < 0 {
+				report.Removed = append(report.Removed, q.Question)
+			}
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+	ai.KnowledgeBase.Questions = result
+	if err := ai.reindex(); err != nil {
+		ai.KnowledgeBase.Questions = existing
+		ai.reindex()
+		return ImportReport{}, err
+	}
+	ai.KnowledgeBase.updateTemplates()
+	return report, nil
+}
+
+// findQuestion mencari indeks pertanyaan dengan teks yang sama persis, atau -1
+func findQuestion(questions []Question, text string) int {
+	for i, q := range questions {
+		if q.Question == text {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameQuestion melaporkan apakah dua pertanyaan memiliki jawaban, hook, dan tag yang sama
+func sameQuestion(a, b Question) bool {
+	return a.Hook == b.Hook && slices.Equal(a.Aliases, b.Aliases) &&
+		slices.Equal(a.Answers, b.Answers) && slices.Equal(a.Tags, b.Tags)
+}
+
+// mergeQuestion menambahkan alias, jawaban, dan tag baru dari q ke existing. Hook
+// hanya diisi jika existing belum memiliki hook.
+func mergeQuestion(existing, q Question) (Question, bool) {
+	changed := false
+	merged := existing
+	merged.Aliases = append([]string(nil), existing.Aliases...)
+	merged.Answers = append([]string(nil), existing.Answers...)
+	merged.Tags = append([]string(nil), existing.Tags...)
+	for _, alias := range q.Aliases {
+		if alias != merged.Question && !contains(merged.Aliases, alias) {
+			merged.Aliases = append(merged.Aliases, alias)
+			changed = true
+		}
+	}
+	for _, answer := range q.Answers {
+		if !contains(merged.Answers, answer) {
+			merged.Answers = append(merged.Answers, answer)
+			changed = true
+		}
+	}
+	for _, tag := range q.Tags {
+		if !contains(merged.Tags, tag) {
+			merged.Tags = append(merged.Tags, tag)
+			changed = true
+		}
+	}
+	if merged.Hook == "" && q.Hook != "" {
+		merged.Hook = q.Hook
+		changed = true
+	}
+	return merged, changed
+}
+
+// ReadCSV membaca pertanyaan dari CSV dengan baris header. Kolom yang dikenali
+// adalah question, aliases, answers, hook, dan tags. Beberapa alias atau jawaban
+// dalam satu sel dipisahkan dengan "||" (karena "|" dipakai oleh variasi {a|b}), dan tag
+// dipisahkan dengan koma. Kolom answer juga diterima, dan setiap barisnya
+// menjadi satu jawaban.
+func ReadCSV(r io.Reader) ([]Question, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["question"]; !ok {
+		return nil, fmt.Errorf("CSV header has no %q column", "question")
+	}
+	cell := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	var questions []Question
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return questions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		q := Question{Question: cell(row, "question"), Hook: cell(row, "hook")}
+		if q.Question == "" {
+			continue
+		}
+		q.Aliases = splitList(cell(row, "aliases"), "||")
+		q.Answers = splitList(cell(row, "answers"), "||")
+		if answer := cell(row, "answer"); answer != "" {
+			q.Answers = append(q.Answers, answer)
+		}
+		q.Tags = splitList(cell(row, "tags"), ",")
+		questions = append(questions, q)
+	}
+}
+
+// jsonQuestion adalah satu baris JSONL. Answers boleh berupa string atau array.
+type jsonQuestion struct {
+	Question string          `json:"question"`
+	Aliases  []string        `json:"aliases"`
+	Answers  json.RawMessage `json:"answers"`
+	Answer   string          `json:"answer"`
+	Hook     string          `json:"hook"`
+	Tags     []string        `json:"tags"`
+}
+
+// ReadJSONL membaca pertanyaan dari JSONL, satu objek per baris dengan field
+// question, aliases, answers (string atau array), hook, dan tags
+func ReadJSONL(r io.Reader) ([]Question, error) {
+	var questions []Question
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var entry jsonQuestion
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		q := Question{Question: strings.TrimSpace(entry.Question), Aliases: entry.Aliases, Hook: entry.Hook, Tags: entry.Tags}
+		if q.Question == "" {
+			return nil, fmt.Errorf("line %d: missing question", line)
+		}
+		if len(entry.Answers) > 0 {
+			var answer string
+			if err := json.Unmarshal(entry.Answers, &answer); err == nil {
+				q.Answers = []string{answer}
+			} else if err := json.Unmarshal(entry.Answers, &q.Answers); err != nil {
+				return nil, fmt.Errorf("line %d: answers must be a string or an array of strings", line)
+			}
+		}
+		if entry.Answer != "" {
+			q.Answers = append(q.Answers, entry.Answer)
+		}
+		questions = append(questions, q)
+	}
+	return questions, scanner.Err()
+}
+
+// ReadMarkdownFAQ membaca dokumen FAQ Markdown. Setiap heading menjadi
+// pertanyaan dan teks di bawahnya menjadi jawaban. Heading tanpa teks, seperti
+// judul dokumen atau nama bagian, dilewati. Blok kode tidak diperiksa untuk
+// heading.
+func ReadMarkdownFAQ(r io.Reader) ([]Question, error) {
+	var questions []Question
+	var heading string
+	var body []string
+	inCode := false
+
+	flush := func() {
+		answer := strings.TrimSpace(strings.Join(body, "\n"))
+		if heading != "" && answer != "" {
+			questions = append(questions, Question{Question: heading, Answers: []string{answer}})
+		}
+		body = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+		}
+		if title, ok := markdownHeading(line); ok && !inCode {
+			flush()
+			heading = title
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+	return questions, scanner.Err()
+}
+
+// markdownHeading mengembalikan teks heading ATX seperti "## Pertanyaan"
+func markdownHeading(line string) (string, bool) {
+	level := 0
+	for level < len(line) && level < 7 && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ' && line[level] != '\t') {
+		return "", false
+	}
+	title := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(line[level:]), "#"))
+	return title, title != ""
+}
+
+// splitList memecah teks dengan pemisah dan membuang bagian yang kosong
+func splitList(text, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(text, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,61 @@
+package beo
+
+import (
+	"math"
+)
+
+// Matching merepresentasikan konfigurasi pencocokan pertanyaan
+type Matching struct {
+	NGram       int     `yaml:"ngram,omitempty"`
+	NGramWeight float64 `yaml:"ngramweight,omitempty"`
+	Distance    string  `yaml:"distance,omitempty"`
+	Keyboard    bool    `yaml:"keyboard,omitempty"`
+}
+
+// defaultNGramWeight adalah bobot skor n-gram karakter jika tidak diatur
+const defaultNGramWeight = 0.3
+
+// ngramWeight mengembalikan bobot skor n-gram karakter dalam rentang 0 sampai 1
+func (m Matching) ngramWeight() float64 {
+	if m.NGramWeight <= 0 {
+		return defaultNGramWeight
+	}
+	return math.Min(m.NGramWeight, 1)
+}
+
+// SetMatching mengganti konfigurasi pencocokan dan membangun ulang indeks
+func (ai *AI) SetMatching(matching Matching) error {
+	previous := ai.KnowledgeBase.Matching
+	ai.KnowledgeBase.Matching = matching
+	if err := ai.reindex(); err != nil {
+		ai.KnowledgeBase.Matching = previous
+		return err
+	}
+	return nil
+}
+
+// charNGrams memecah token menjadi n-gram karakter. Token digabung tanpa spasi
+// dan diberi penanda awal dan akhir, sehingga "log in" dan "login" menghasilkan
+// n-gram yang sama dan kata berimbuhan tetap berbagi n-gram dengan kata dasarnya.
+func charNGrams(tokens []string, n int) []string {
+	if len(tokens) == 0 || n <= 0 {
+		return nil
+	}
+
+	var text []rune
+	text = append(text, '^')
+	for _, token := range tokens {
+		text = append(text, []rune(token)...)
+	}
+	text = append(text, '$')
+
+	if len(text) <= n {
+		return []string{string(text)}
+	}
+
+	grams := make([]string, 0, len(text)-n+1)
+	for i := 0; i+n <= len(text); i++ {
+		grams = append(grams, string(text[i:i+n]))
+	}
+	return grams
+}
@@ -0,0 +1,212 @@
+package beo
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TextFilter adalah satu tahap pipeline normalisasi teks yang memproses token
+// hasil tokenisasi, misalnya stemming atau penghapusan stopword
+type TextFilter interface {
+	Filter(tokens []string) []string
+}
+
+// TextFilterFunc mengubah fungsi biasa menjadi TextFilter
+type TextFilterFunc func(tokens []string) []string
+
+// Filter memanggil f(tokens)
+func (f TextFilterFunc) Filter(tokens []string) []string {
+	return f(tokens)
+}
+
+// textFilterFactory membuat tahap pipeline berdasarkan konfigurasi knowledge base
+type textFilterFactory func(kb *KnowledgeBase) (TextFilter, error)
+
+// Daftar tahap pipeline yang dapat dipakai berdasarkan nama di YAML
+var (
+	textFiltersMu sync.RWMutex
+	textFilters   = map[string]textFilterFactory{
+		"numbers": func(kb *KnowledgeBase) (TextFilter, error) {
+			return TextFilterFunc(normalizeNumbers), nil
+		},
+		"emoji": func(kb *KnowledgeBase) (TextFilter, error) {
+			return TextFilterFunc(emojiToText), nil
+		},
+		"stem":      newStemFilter,
+		"stopwords": newStopwordFilter,
+		"synonyms":  newSynonymFilter,
+	}
+)
+
+// RegisterTextFilter mendaftarkan tahap pipeline kustom agar dapat dipakai
+// lewat namanya di bagian pipeline knowledge base. Nama yang sudah ada ditimpa.
+func RegisterTextFilter(name string, filter TextFilter) {
+	textFiltersMu.Lock()
+	defer textFiltersMu.Unlock()
+	textFilters[name] = func(kb *KnowledgeBase) (TextFilter, error) {
+		return filter, nil
+	}
+}
+
+// SetPipeline mengganti pipeline normalisasi teks dan membangun ulang indeks.
+// Tanpa argumen, pipeline disusun dari konfigurasi knowledge base.
+func (ai *AI) SetPipeline(names ...string) error {
+	previous := ai.KnowledgeBase.Pipeline
+	ai.KnowledgeBase.Pipeline = names
+	if err := ai.reindex(); err != nil {
+		ai.KnowledgeBase.Pipeline = previous
+		return err
+	}
+	return nil
+}
+
+// SetStopwords mengganti konfigurasi stopword dan membangun ulang indeks
+func (ai *AI) SetStopwords(stopwords Stopwords) error {
+	previous := ai.KnowledgeBase.Stopwords
+	ai.KnowledgeBase.Stopwords = stopwords
+	if err := ai.reindex(); err != nil {
+		ai.KnowledgeBase.Stopwords = previous
+		return err
+	}
+	return nil
+}
+
+// SetStemmer memilih stemmer bawaan ("id" atau "en", kosong untuk menonaktifkan)
+// dan membangun ulang indeks
+func (ai *AI) SetStemmer(language string) error {
+	previous := ai.KnowledgeBase.Stemmer
+	ai.KnowledgeBase.Stemmer = language
+	if err := ai.reindex(); err != nil {
+		ai.KnowledgeBase.Stemmer = previous
+		return err
+	}
+	return nil
+}
+
+// reindex menyusun ulang pipeline lalu memperbarui IDF dan kosakata
+func (ai *AI) reindex() error {
+	if err := ai.KnowledgeBase.updatePipeline(); err != nil {
+		return err
+	}
+	ai.KnowledgeBase.updateIDF()
+	ai.KnowledgeBase.updateVocabularies()
+	return nil
+}
+
+// updatePipeline menyusun ulang tahap-tahap pipeline knowledge base
+func (kb *KnowledgeBase) updatePipeline() error {
+	filters, err := kb.buildPipeline()
+	if err != nil {
+		return err
+	}
+	kb.filters = filters
+	return nil
+}
+
+// buildPipeline membuat tahap-tahap pipeline dari nama yang dideklarasikan
+func (kb *KnowledgeBase) buildPipeline() ([]TextFilter, error) {
+	textFiltersMu.RLock()
+	defer textFiltersMu.RUnlock()
+
+	names := kb.Pipeline
+	if len(names) == 0 {
+		names = kb.defaultPipeline()
+	}
+
+	filters := make([]TextFilter, 0, len(names))
+	for _, name := range names {
+		factory, ok := textFilters[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown pipeline stage %q", name)
+		}
+		filter, err := factory(kb)
+		if err != nil {
+			return nil, fmt.Errorf("pipeline stage %q: %w", name, err)
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// defaultPipeline menyusun pipeline dari bagian konfigurasi knowledge base
+// jika pipeline tidak dideklarasikan secara eksplisit
+func (kb *KnowledgeBase) defaultPipeline() []string {
+	var names []string
+	if len(kb.Synonyms) > 0 {
+		names = append(names, "synonyms")
+	}
+	if kb.Stopwords.enabled() {
+		names = append(names, "stopwords")
+	}
+	if kb.Stemmer != "" {
+		names = append(names, "stem")
+	}
+	return names
+}
+
+// analyze mentokenisasi teks lalu menjalankan seluruh tahap pipeline.
+// Fungsi ini dipakai untuk pertanyaan, input pengguna, dan kosakata.
+func (kb *KnowledgeBase) analyze(text string) []string {
+	tokens := tokenize(text)
+	for _, filter := range kb.filters {
+		tokens = filter.Filter(tokens)
+	}
+	return tokens
+}
+
+// numberWords memetakan kata bilangan bahasa Inggris dan Indonesia ke angka
+var numberWords = map[string]string{
+	"zero": "0", "one": "1", "two": "2", "three": "3", "four": "4",
+	"five": "5", "six": "6", "seven": "7", "eight": "8", "nine": "9", "ten": "10",
+	"nol": "0", "satu": "1", "dua": "2", "tiga": "3", "empat": "4",
+	"lima": "5", "enam": "6", "tujuh": "7", "delapan": "8", "sembilan": "9", "sepuluh": "10",
+}
+
+// normalizeNumbers mengubah kata bilangan menjadi angka dan menghapus pemisah ribuan
+func normalizeNumbers(tokens []string) []string {
+	result := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if number, ok := numberWords[token]; ok {
+			token = number
+		} else if isNumeric(token) {
+			token = strings.ReplaceAll(token, ",", "")
+		}
+		result = append(result, token)
+	}
+	return result
+}
+
+// isNumeric mengecek apakah token hanya berisi angka dan pemisah
+func isNumeric(token string) bool {
+	for _, r := range token {
+		if (r < '0' || r > '9') && r != '.' && r != ',' {
+			return false
+		}
+	}
+	return token != ""
+}
+
+// emojiWords memetakan emoji yang umum ke kata yang setara
+var emojiWords = map[string]string{
+	"😀": "happy", "😃": "happy", "😄": "happy", "😁": "happy", "🙂": "happy", "😊": "happy",
+	"😂": "laugh", "🤣": "laugh", "😢": "sad", "😭": "sad", "☹": "sad", "🙁": "sad",
+	"😡": "angry", "😠": "angry", "😍": "love", "❤": "love", "♥": "love", "💕": "love",
+	"👍": "yes", "👎": "no", "👋": "hello", "🙏": "thanks", "🤔": "think", "😴": "sleep",
+	"🍕": "pizza", "☕": "coffee", "💰": "money", "💵": "money", "📞": "phone", "📧": "email",
+	"🕒": "time", "⏰": "time", "📅": "date", "🎂": "birthday", "🎉": "celebrate",
+}
+
+// emojiToText mengganti token emoji dengan kata yang setara. Emoji yang tidak
+// dikenal dibuang agar tidak menjadi token yang tidak bermakna.
+func emojiToText(tokens []string) []string {
+	result := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if word, ok := emojiWords[token]; ok {
+			result = append(result, word)
+		} else if !isSymbolToken(token) {
+			result = append(result, token)
+		}
+	}
+	return result
+}
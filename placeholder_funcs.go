@@ -0,0 +1,128 @@
+package beo
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// placeholderFunction memproses placeholder berargumen seperti %date:+1d%
+type placeholderFunction func(ctx context.Context, ai *AI, arg string) (string, bool)
+
+// placeholderFunctions adalah pustaka fungsi placeholder bawaan
+var placeholderFunctions = map[string]placeholderFunction{
+	"date": func(ctx context.Context, ai *AI, arg string) (string, bool) {
+		layout := ai.KnowledgeBase.Formats.Date
+		if layout == "" {
+			layout = "2006-01-02"
+		}
+		return formatTimeArgs(ai.KnowledgeBase.Formats, layout, arg)
+	},
+	"time": func(ctx context.Context, ai *AI, arg string) (string, bool) {
+		layout := ai.KnowledgeBase.Formats.Time
+		if layout == "" {
+			layout = "15:04:05"
+		}
+		return formatTimeArgs(ai.KnowledgeBase.Formats, layout, arg)
+	},
+	"upper": transformPlaceholder(strings.ToUpper),
+	"lower": transformPlaceholder(strings.ToLower),
+	"title": transformPlaceholder(titleCase),
+}
+
+// timeLayouts adalah nama format waktu yang dapat dipakai sebagai argumen
+var timeLayouts = map[string]string{
+	"iso":     "2006-01-02",
+	"rfc3339": time.RFC3339,
+	"rfc1123": time.RFC1123,
+	"kitchen": time.Kitchen,
+	"short":   "02/01/2006",
+	"long":    "Monday, 02 January 2006",
+	"weekday": "Monday",
+	"month":   "January",
+	"year":    "2006",
+	"clock":   "15:04",
+}
+
+var timeOffsetPattern = regexp.MustCompile(`^([+-]\d+)(mo|[smhdwy])$`)
+
+// formatTimeArgs memformat waktu sekarang berdasarkan argumen yang dipisah koma.
+// Setiap argumen dapat berupa pergeseran waktu (+1d, -2h, +1mo), nama zona waktu
+// (Asia/Jakarta), nama format (iso, long, kitchen, ...), atau layout Go (15:04).
+func formatTimeArgs(formats Formats, layout, arg string) (string, bool) {
+	now := currentTime(formats)
+
+	for _, part := range strings.Split(arg, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if match := timeOffsetPattern.FindStringSubmatch(part); match != nil {
+			amount, err := strconv.Atoi(match[1])
+			if err != nil {
+				return "", false
+			}
+			now = shiftTime(now, amount, match[2])
+		} else if named, ok := timeLayouts[strings.ToLower(part)]; ok {
+			layout = named
+		} else if zone, err := time.LoadLocation(part); err == nil {
+			now = now.In(zone)
+		} else {
+			layout = part
+		}
+	}
+
+	return now.Format(layout), true
+}
+
+// shiftTime menggeser waktu sesuai satuan pergeseran
+func shiftTime(t time.Time, amount int, unit string) time.Time {
+	switch unit {
+	case "s":
+		return t.Add(time.Duration(amount) * time.Second)
+	case "m":
+		return t.Add(time.Duration(amount) * time.Minute)
+	case "h":
+		return t.Add(time.Duration(amount) * time.Hour)
+	case "d":
+		return t.AddDate(0, 0, amount)
+	case "w":
+		return t.AddDate(0, 0, amount*7)
+	case "mo":
+		return t.AddDate(0, amount, 0)
+	case "y":
+		return t.AddDate(amount, 0, 0)
+	}
+	return t
+}
+
+// transformPlaceholder membuat fungsi yang mengubah nilai placeholder lain,
+// misalnya %upper:name% menghasilkan nilai %name% dalam huruf kapital
+func transformPlaceholder(transform func(string) string) placeholderFunction {
+	return func(ctx context.Context, ai *AI, arg string) (string, bool) {
+		value, ok := ai.lookupPlaceholder(ctx, strings.TrimSpace(arg))
+		if !ok {
+			return "", false
+		}
+		return transform(value), true
+	}
+}
+
+// titleCase mengubah huruf pertama setiap kata menjadi kapital
+func titleCase(text string) string {
+	var builder strings.Builder
+	startOfWord := true
+	for _, r := range text {
+		if startOfWord {
+			builder.WriteRune(unicode.ToUpper(r))
+		} else {
+			builder.WriteRune(r)
+		}
+		startOfWord = unicode.IsSpace(r)
+	}
+	return builder.String()
+}
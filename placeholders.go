@@ -1,48 +1,168 @@
 package beo
 
 import (
+	"context"
 	"regexp"
-	"strings"
 	"time"
 )
 
-// processPlaceholders memproses placeholder seperti %date% dan %time%
-// Mengambil format dari knowledge base jika tersedia
-func processPlaceholders(answer string, kb KnowledgeBase) string {
-	formats := kb.Formats
-	placeholders := kb.Placeholders
+// PlaceholderFunc menghasilkan nilai placeholder secara dinamis saat jawaban diproses
+type PlaceholderFunc func(ctx context.Context) string
+
+// Slots adalah nilai placeholder per permintaan, misalnya %user% dari lapisan autentikasi
+type Slots map[string]string
+
+type slotsKey struct{}
+
+// WithSlots mengembalikan context baru yang membawa nilai placeholder per permintaan.
+// Slot yang sudah ada pada ctx digabungkan, dengan nilai baru menimpa nilai lama.
+func WithSlots(ctx context.Context, slots Slots) context.Context {
+	merged := Slots{}
+	for key, value := range slotsFromContext(ctx) {
+		merged[key] = value
+	}
+	for key, value := range slots {
+		merged[key] = value
+	}
+	return context.WithValue(ctx, slotsKey{}, merged)
+}
+
+// slotsFromContext mengambil slot yang dibawa oleh ctx
+func slotsFromContext(ctx context.Context) Slots {
+	slots, _ := ctx.Value(slotsKey{}).(Slots)
+	return slots
+}
+
+// placeholderPattern mencocokkan %% (tanda persen literal) atau placeholder
+// berbentuk %nama%, %nama:argumen%, %nama|default%, atau %nama:argumen|default%
+var placeholderPattern = regexp.MustCompile(`%%|%(\w+)(?::([^%|]*))?(?:\|([^%]*))?%`)
 
-	// Periksa apakah ada placeholder %date% atau %time% dalam string
-	if regexp.MustCompile(`(%date%|%time%)`).MatchString(answer) {
-		zone, _ := time.LoadLocation(formats.TimeZone)
-		if zone == nil {
-			zone = time.Local
+// RegisterPlaceholder mendaftarkan penyedia placeholder dinamis.
+// Penyedia tidak disimpan ke file.
+func (ai *AI) RegisterPlaceholder(name string, fn PlaceholderFunc) {
+	if ai.placeholderFuncs == nil {
+		ai.placeholderFuncs = make(map[string]PlaceholderFunc)
+	}
+	ai.placeholderFuncs[name] = fn
+}
+
+// lookupPlaceholder mencari nilai placeholder dengan urutan prioritas:
+// slot per permintaan, variabel sesi, penyedia dinamis, placeholder statis
+// di knowledge base, lalu placeholder bawaan (%date%, %time%, %ainame%, %model%, %trainer%).
+func (ai *AI) lookupPlaceholder(ctx context.Context, key string) (string, bool) {
+	if value, exists := slotsFromContext(ctx)[key]; exists {
+		return value, true
+	}
+	if session := sessionFromContext(ctx); session != nil {
+		if value, exists := session.Get(key); exists {
+			return value, true
 		}
-		currentTime := time.Now().In(zone)
+	}
+	if fn, exists := ai.placeholderFuncs[key]; exists {
+		return fn(ctx), true
+	}
+	if value, exists := ai.KnowledgeBase.Placeholders[key]; exists {
+		return value, true
+	}
+	return builtinPlaceholder(key, ai.KnowledgeBase)
+}
+
+// builtinPlaceholder memproses placeholder bawaan seperti %date% dan %time%
+// Mengambil format dari knowledge base jika tersedia
+func builtinPlaceholder(key string, kb KnowledgeBase) (string, bool) {
+	formats := kb.Formats
 
-		// Proses placeholder %date%
+	switch key {
+	case "date":
 		if formats.Date == "" {
 			formats.Date = "2006-01-02"
 		}
-		answer = strings.ReplaceAll(answer, "%date%", currentTime.Format(formats.Date))
-
-		// Proses placeholder %time%
+		return currentTime(formats).Format(formats.Date), true
+	case "time":
 		if formats.Time == "" {
 			formats.Time = "15:04:05"
 		}
-		answer = strings.ReplaceAll(answer, "%time%", currentTime.Format(formats.Time))
+		return currentTime(formats).Format(formats.Time), true
+	case "ainame":
+		return kb.AIName, true
+	case "model":
+		return kb.Model, true
+	case "trainer":
+		return kb.Trainer, true
 	}
+	return "", false
+}
 
-	answer = strings.ReplaceAll(answer, "%ainame%", kb.AIName)
-	answer = strings.ReplaceAll(answer, "%model%", kb.Model)
-	answer = strings.ReplaceAll(answer, "%trainer%", kb.Trainer)
+// currentTime mengembalikan waktu sekarang pada zona waktu knowledge base
+func currentTime(formats Formats) time.Time {
+	zone, _ := time.LoadLocation(formats.TimeZone)
+	if zone == nil {
+		zone = time.Local
+	}
+	return time.Now().In(zone)
+}
 
-	re := regexp.MustCompile(`%(\w+)%`)
-	return re.ReplaceAllStringFunc(answer, func(match string) string {
-		key := match[1 : len(match)-1]
-		if value, exists := placeholders[key]; exists {
-			return value
+// processPlaceholders mengganti setiap placeholder dalam jawaban dengan nilainya.
+// Placeholder tanpa nilai memakai default-nya (%user|teman%) jika ada,
+// selain itu dibiarkan apa adanya. %% menghasilkan tanda persen literal.
+func (ai *AI) processPlaceholders(ctx context.Context, answer string) (string, error) {
+	var err error
+	result := placeholderPattern.ReplaceAllStringFunc(answer, func(match string) string {
+		if err != nil {
+			return match
+		}
+		if err = ctx.Err(); err != nil {
+			return match
 		}
-		return match
+		if match == "%%" {
+			return "%"
+		}
+
+		value := ai.replacePlaceholder(ctx, match)
+		if trace := traceFromContext(ctx); trace != nil {
+			trace.substitutions = append(trace.substitutions, Substitution{Placeholder: match, Value: value})
+		}
+		return value
 	})
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// replacePlaceholder menghasilkan pengganti untuk satu placeholder
+func (ai *AI) replacePlaceholder(ctx context.Context, match string) string {
+	// Indeks grup: 1 nama, 2 argumen, 3 default (-1 jika tidak ada)
+	index := placeholderPattern.FindStringSubmatchIndex(match)
+	key := match[index[2]:index[3]]
+	hasArg, hasDefault := index[4] >= 0, index[6] >= 0
+
+	var arg string
+	if hasArg {
+		arg = match[index[4]:index[5]]
+	}
+	value, exists := ai.resolvePlaceholder(ctx, key, arg, hasArg)
+	if exists && value != "" {
+		return value
+	}
+	if hasDefault {
+		return match[index[6]:index[7]]
+	}
+	if exists {
+		return value
+	}
+	return match
+}
+
+// resolvePlaceholder menyelesaikan satu placeholder. Placeholder berargumen
+// diproses oleh pustaka fungsi, sedangkan placeholder biasa dicari lewat lookupPlaceholder.
+func (ai *AI) resolvePlaceholder(ctx context.Context, key, arg string, hasArg bool) (string, bool) {
+	if hasArg {
+		fn, exists := placeholderFunctions[key]
+		if !exists {
+			return "", false
+		}
+		return fn(ctx, ai, arg)
+	}
+	return ai.lookupPlaceholder(ctx, key)
 }
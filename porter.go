@@ -0,0 +1,262 @@
+package beo
+
+// porterStemmer menyimpan keadaan algoritma Porter untuk satu kata.
+// b berisi huruf kata, k adalah indeks huruf terakhir, dan j adalah
+// indeks akhir kata dasar sementara setelah akhiran ditemukan.
+type porterStemmer struct {
+	b    []byte
+	k, j int
+}
+
+// stemEnglish mencari kata dasar bahasa Inggris dengan algoritma Porter (1980).
+// Kata yang berisi huruf selain a-z dibiarkan apa adanya.
+func stemEnglish(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	p := &porterStemmer{b: []byte(word), k: len(word) - 1}
+	p.step1ab()
+	if p.k > 0 {
+		p.step1c()
+		p.step2()
+		p.step3()
+		p.step4()
+		p.step5()
+	}
+	return string(p.b[:p.k+1])
+}
+
+// cons mengecek apakah huruf pada indeks i merupakan konsonan
+func (p *porterStemmer) cons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		if i == 0 {
+			return true
+		}
+		return !p.cons(i - 1)
+	}
+	return true
+}
+
+// m menghitung jumlah urutan vokal-konsonan pada b[0..j]
+func (p *porterStemmer) m() int {
+	n, i := 0, 0
+	for {
+		if i > p.j {
+			return n
+		}
+		if !p.cons(i) {
+			break
+		}
+		i++
+	}
+	i++
+	for {
+		for {
+			if i > p.j {
+				return n
+			}
+			if p.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+		n++
+		for {
+			if i > p.j {
+				return n
+			}
+			if !p.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+	}
+}
+
+// vowelInStem mengecek apakah b[0..j] mengandung vokal
+func (p *porterStemmer) vowelInStem() bool {
+	for i := 0; i <= p.j; i++ {
+		if !p.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doublec mengecek apakah b[j-1..j] adalah konsonan ganda
+func (p *porterStemmer) doublec(j int) bool {
+	if j < 1 || p.b[j] != p.b[j-1] {
+		return false
+	}
+	return p.cons(j)
+}
+
+// cvc mengecek pola konsonan-vokal-konsonan pada b[i-2..i], dengan
+// konsonan terakhir bukan w, x, atau y
+func (p *porterStemmer) cvc(i int) bool {
+	if i < 2 || !p.cons(i) || p.cons(i-1) || !p.cons(i-2) {
+		return false
+	}
+	switch p.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends mengecek akhiran dan mengatur j ke akhir kata dasar jika cocok
+func (p *porterStemmer) ends(s string) bool {
+	length := len(s)
+	if length > p.k+1 {
+		return false
+	}
+	if string(p.b[p.k-length+1:p.k+1]) != s {
+		return false
+	}
+	p.j = p.k - length
+	return true
+}
+
+// setTo mengganti b[j+1..k] dengan s
+func (p *porterStemmer) setTo(s string) {
+	p.b = append(p.b[:p.j+1], s...)
+	p.k = p.j + len(s)
+}
+
+// r mengganti akhiran dengan s jika m() > 0
+func (p *porterStemmer) r(s string) {
+	if p.m() > 0 {
+		p.setTo(s)
+	}
+}
+
+// step1ab menghapus bentuk jamak dan akhiran -ed atau -ing
+func (p *porterStemmer) step1ab() {
+	if p.b[p.k] == 's' {
+		switch {
+		case p.ends("sses"):
+			p.k -= 2
+		case p.ends("ies"):
+			p.setTo("i")
+		case p.b[p.k-1] != 's':
+			p.k--
+		}
+	}
+
+	if p.ends("eed") {
+		if p.m() > 0 {
+			p.k--
+		}
+	} else if (p.ends("ed") || p.ends("ing")) && p.vowelInStem() {
+		p.k = p.j
+		switch {
+		case p.ends("at"):
+			p.setTo("ate")
+		case p.ends("bl"):
+			p.setTo("ble")
+		case p.ends("iz"):
+			p.setTo("ize")
+		case p.doublec(p.k):
+			p.k--
+			switch p.b[p.k] {
+			case 'l', 's', 'z':
+				p.k++
+			}
+		default:
+			p.j = p.k
+			if p.m() == 1 && p.cvc(p.k) {
+				p.setTo("e")
+			}
+		}
+	}
+}
+
+// step1c mengubah y di akhir menjadi i jika ada vokal lain dalam kata dasar
+func (p *porterStemmer) step1c() {
+	if p.ends("y") && p.vowelInStem() {
+		p.b[p.k] = 'i'
+	}
+}
+
+// porterStep2 memetakan akhiran ganda menjadi akhiran tunggal
+var porterStep2 = []struct{ from, to string }{
+	{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"},
+	{"izer", "ize"}, {"bli", "ble"}, {"alli", "al"}, {"entli", "ent"},
+	{"eli", "e"}, {"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"},
+	{"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"},
+	{"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+	{"logi", "log"},
+}
+
+// step2 mengganti akhiran ganda seperti -ization menjadi -ize
+func (p *porterStemmer) step2() {
+	for _, rule := range porterStep2 {
+		if p.ends(rule.from) {
+			p.r(rule.to)
+			return
+		}
+	}
+}
+
+// porterStep3 memetakan akhiran -ic-, -full, -ness, dan sejenisnya
+var porterStep3 = []struct{ from, to string }{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"},
+	{"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+// step3 menangani akhiran -ic-, -full, -ness, dan sejenisnya
+func (p *porterStemmer) step3() {
+	for _, rule := range porterStep3 {
+		if p.ends(rule.from) {
+			p.r(rule.to)
+			return
+		}
+	}
+}
+
+// porterStep4 adalah akhiran yang dihapus jika m() > 1
+var porterStep4 = []string{
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement",
+	"ment", "ent", "ion", "ou", "ism", "ate", "iti", "ous", "ive", "ize",
+}
+
+// step4 menghapus akhiran seperti -ant dan -ence jika m() > 1
+func (p *porterStemmer) step4() {
+	for _, suffix := range porterStep4 {
+		if !p.ends(suffix) {
+			continue
+		}
+		if suffix == "ion" && (p.j < 0 || (p.b[p.j] != 's' && p.b[p.j] != 't')) {
+			return
+		}
+		if p.m() > 1 {
+			p.k = p.j
+		}
+		return
+	}
+}
+
+// step5 menghapus -e di akhir dan mengubah -ll menjadi -l jika m() > 1
+func (p *porterStemmer) step5() {
+	p.j = p.k
+	if p.b[p.k] == 'e' {
+		a := p.m()
+		if a > 1 || (a == 1 && !p.cvc(p.k-1)) {
+			p.k--
+		}
+	}
+	if p.b[p.k] == 'l' && p.doublec(p.k) && p.m() > 1 {
+		p.k--
+	}
+}
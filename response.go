@@ -0,0 +1,17 @@
+package beo
+
+// Response adalah hasil lengkap dari sebuah pertanyaan
+type Response struct {
+	// Text adalah jawaban akhir, sama seperti hasil Ask
+	Text string `json:"text"`
+	// Answers berisi jawaban per pertanyaan sesuai urutan dalam input
+	Answers []Answer `json:"answers,omitempty"`
+	// Corrections berisi kata input yang dikoreksi sebelum pencocokan
+	Corrections []Correction `json:"corrections,omitempty"`
+	// Corrected adalah input dengan kata yang sudah dikoreksi, atau kosong jika tidak ada koreksi
+	Corrected string `json:"corrected,omitempty"`
+	// Candidates berisi pertanyaan yang ditawarkan jika Text adalah pesan klarifikasi
+	Candidates []string `json:"candidates,omitempty"`
+	// Fallback adalah jenis fallback yang dipakai (FallbackNoAnswer, dst.), atau kosong
+	Fallback string `json:"fallback,omitempty"`
+}
@@ -0,0 +1,40 @@
+package beo
+
+import "sort"
+
+// SearchResult adalah pertanyaan hasil pencarian beserta skor kemiripannya
+type SearchResult struct {
+	Question Question `json:"question"`
+	Score    float64  `json:"score"`
+}
+
+// Search mengembalikan hingga k pertanyaan yang paling mirip dengan seluruh query,
+// diurutkan dari skor tertinggi. Query diproses dengan pipeline dan koreksi typo
+// yang sama seperti Ask, tetapi tidak dipecah per kalimat. Pertanyaan dengan
+// skor 0 tidak disertakan.
+func (ai *AI) Search(query string, k int) []SearchResult {
+	kb := &ai.KnowledgeBase
+	tokens, _ := correctInput(kb.analyze(query), kb.index())
+	if len(tokens) == 0 || k <= 0 {
+		return nil
+	}
+
+	scorer := newScorer(kb)
+	words, chars := scorer.inputVectors(tokens)
+
+	var results []SearchResult
+	for i, question := range kb.Questions {
+		if score := scorer.similarity(words, chars, i); score > 0 {
+			results = append(results, SearchResult{Question: question, Score: score})
+		}
+	}
+
+	// Urutan pelatihan dipertahankan untuk skor yang sama
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > k {
+		results = results[:k]
+	}
+	return results
+}
@@ -0,0 +1,167 @@
+package beo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Segmentation mengatur cara input dipecah menjadi beberapa pertanyaan
+type Segmentation struct {
+	// Abbreviations adalah singkatan tambahan yang titiknya bukan akhir kalimat
+	Abbreviations []string `yaml:"abbreviations,omitempty"`
+	// SplitConjunctions memecah kalimat pada kata hubung seperti "and" dan "dan"
+	SplitConjunctions bool `yaml:"splitconjunctions,omitempty"`
+	// Conjunctions mengganti daftar kata hubung bawaan
+	Conjunctions []string `yaml:"conjunctions,omitempty"`
+}
+
+// defaultAbbreviations adalah singkatan bahasa Inggris dan Indonesia yang umum,
+// ditulis tanpa titik terakhir. Kata yang juga sering menjadi kalimat sendiri
+// atau akhir kalimat (no, co, st) tidak dimasukkan.
+var defaultAbbreviations = []string{
+	"mr", "mrs", "ms", "dr", "prof", "sr", "jr", "vs", "etc", "e.g", "i.e",
+	"approx", "dept", "inc", "ltd", "jan", "feb", "mar", "apr", "jun",
+	"jul", "aug", "sep", "sept", "oct", "nov", "dec",
+	"jl", "dll", "dsb", "dst", "yth", "bpk", "sdr", "pt", "tbk", "a.n", "u.p", "s.d",
+}
+
+// numberAbbreviations adalah singkatan yang titiknya hanya bukan akhir kalimat
+// jika diikuti angka (No. 5), karena "No." juga dapat berupa jawaban singkat
+var numberAbbreviations = map[string]bool{"no": true}
+
+// defaultConjunctions adalah kata hubung bawaan untuk memecah pertanyaan
+var defaultConjunctions = []string{"and", "also", "dan", "juga", "serta"}
+
+// minConjunctionWords adalah jumlah kata minimum di kedua sisi kata hubung agar
+// kalimat dipecah, sehingga frasa seperti "salt and pepper" tetap utuh
+const minConjunctionWords = 2
+
+// SetSegmentation mengganti konfigurasi pemecahan input
+func (ai *AI) SetSegmentation(segmentation Segmentation) {
+	ai.KnowledgeBase.Segmentation = segmentation
+}
+
+// segment memecah input menjadi beberapa pertanyaan sesuai konfigurasi knowledge base
+func (kb *KnowledgeBase) segment(input string) []string {
+	abbreviations := map[string]bool{}
+	for _, abbreviation := range defaultAbbreviations {
+		abbreviations[abbreviation] = true
+	}
+	for _, abbreviation := range kb.Segmentation.Abbreviations {
+		abbreviations[strings.TrimSuffix(strings.ToLower(abbreviation), ".")] = true
+	}
+
+	sentences := splitSentences(input, abbreviations)
+	if !kb.Segmentation.SplitConjunctions {
+		return sentences
+	}
+
+	conjunctions := kb.Segmentation.Conjunctions
+	if len(conjunctions) == 0 {
+		conjunctions = defaultConjunctions
+	}
+	var segments []string
+	for _, sentence := range sentences {
+		segments = append(segments, splitConjunctions(sentence, conjunctions)...)
+	}
+	return segments
+}
+
+// splitSentences memecah input pada ?, !, baris baru, dan titik akhir kalimat.
+// Titik tidak dianggap akhir kalimat jika berada di antara angka (v1.2, 3.14),
+// di dalam kata (example.com, e.g), setelah singkatan (Dr. Smith), atau
+// setelah inisial satu huruf (J. Smith).
+func splitSentences(input string, abbreviations map[string]bool) []string {
+	runes := []rune(input)
+	var sentences []string
+	start := 0
+
+	flush := func(end int) {
+		if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end + 1
+	}
+
+	for i, r := range runes {
+		switch r {
+		case '?', '!', '\n':
+			flush(i)
+		case '.':
+			if isSentencePeriod(runes, i, abbreviations) {
+				flush(i)
+			}
+		}
+	}
+	flush(len(runes))
+	return sentences
+}
+
+// isSentencePeriod mengecek apakah titik pada indeks i mengakhiri kalimat
+func isSentencePeriod(runes []rune, i int, abbreviations map[string]bool) bool {
+	// Titik di dalam kata atau angka, misalnya v1.2 atau example.com
+	if i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && !strings.ContainsRune(".?!\"')", runes[i+1]) {
+		return false
+	}
+
+	// Kata sebelum titik, termasuk titik di dalamnya (e.g)
+	wordStart := i
+	for wordStart > 0 && (isWordRune(runes[wordStart-1]) || runes[wordStart-1] == '.') {
+		wordStart--
+	}
+	word := strings.ToLower(string(runes[wordStart:i]))
+	if word == "" {
+		return true
+	}
+	if abbreviations[word] || (numberAbbreviations[word] && nextIsDigit(runes, i)) {
+		return false
+	}
+
+	// Inisial satu huruf yang diikuti nama, misalnya "J. Smith"
+	letters := []rune(word)
+	return !(len(letters) == 1 && unicode.IsLetter(letters[0]) && nextIsUpper(runes, i))
+}
+
+// nextIsUpper mengecek apakah huruf pertama setelah indeks i adalah huruf kapital
+func nextIsUpper(runes []rune, i int) bool {
+	for _, r := range runes[i+1:] {
+		if !unicode.IsSpace(r) {
+			return unicode.IsUpper(r)
+		}
+	}
+	return false
+}
+
+// nextIsDigit mengecek apakah karakter pertama setelah indeks i adalah angka
+func nextIsDigit(runes []rune, i int) bool {
+	for _, r := range runes[i+1:] {
+		if !unicode.IsSpace(r) {
+			return unicode.IsDigit(r)
+		}
+	}
+	return false
+}
+
+// splitConjunctions memecah kalimat pada kata hubung jika kedua sisi memiliki
+// setidaknya minConjunctionWords kata. Kata hubung sendiri tidak disertakan.
+func splitConjunctions(sentence string, conjunctions []string) []string {
+	isConjunction := map[string]bool{}
+	for _, conjunction := range conjunctions {
+		isConjunction[strings.ToLower(conjunction)] = true
+	}
+
+	words := strings.Fields(sentence)
+	var segments []string
+	start := 0
+	for i, word := range words {
+		if !isConjunction[strings.ToLower(strings.Trim(word, ",;"))] {
+			continue
+		}
+		if i-start < minConjunctionWords || len(words)-i-1 < minConjunctionWords {
+			continue
+		}
+		segments = append(segments, strings.Trim(strings.Join(words[start:i], " "), ",; "))
+		start = i + 1
+	}
+	return append(segments, strings.Join(words[start:], " "))
+}
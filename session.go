@@ -0,0 +1,102 @@
+package beo
+
+import (
+	"context"
+	"sync"
+)
+
+// Session menyimpan variabel percakapan yang bertahan di antara beberapa pertanyaan
+type Session struct {
+	mu   sync.Mutex
+	vars map[string]string
+
+	// candidates adalah pertanyaan yang ditawarkan pada klarifikasi terakhir
+	candidates []string
+	// tags adalah tag pertanyaan terakhir yang dijawab, untuk fallback per topik
+	tags []string
+}
+
+// NewSession membuat sesi percakapan baru yang kosong
+func NewSession() *Session {
+	return &Session{vars: make(map[string]string)}
+}
+
+// Set menyimpan variabel sesi
+func (s *Session) Set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vars[key] = value
+}
+
+// Get mengambil variabel sesi
+func (s *Session) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.vars[key]
+	return value, ok
+}
+
+// Vars mengembalikan salinan seluruh variabel sesi
+func (s *Session) Vars() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vars := make(map[string]string, len(s.vars))
+	for key, value := range s.vars {
+		vars[key] = value
+	}
+	return vars
+}
+
+type sessionKey struct{}
+
+// WithSession mengembalikan context baru yang membawa sesi percakapan
+func WithSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// sessionFromContext mengambil sesi yang dibawa oleh ctx, atau nil jika tidak ada
+func sessionFromContext(ctx context.Context) *Session {
+	session, _ := ctx.Value(sessionKey{}).(*Session)
+	return session
+}
+
+// setCandidates menyimpan kandidat klarifikasi yang menunggu pilihan pengguna
+func (s *Session) setCandidates(candidates []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.candidates = candidates
+}
+
+// takeCandidates mengambil lalu menghapus kandidat klarifikasi yang tertunda
+func (s *Session) takeCandidates() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	candidates := s.candidates
+	s.candidates = nil
+	return candidates
+}
+
+// setTopic menyimpan tag pertanyaan terakhir yang dijawab
+func (s *Session) setTopic(tags []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tags = tags
+}
+
+// topic mengembalikan tag pertanyaan terakhir yang dijawab
+func (s *Session) topic() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tags
+}
+
+// clone menyalin seluruh isi sesi, termasuk klarifikasi yang tertunda
+func (s *Session) clone() *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := &Session{vars: make(map[string]string, len(s.vars)), candidates: s.candidates, tags: s.tags}
+	for key, value := range s.vars {
+		copied.vars[key] = value
+	}
+	return copied
+}
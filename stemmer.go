@@ -0,0 +1,311 @@
+package beo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stemmer mengubah sebuah kata menjadi bentuk dasarnya
+type Stemmer func(word string) string
+
+// stemmers adalah stemmer bawaan yang dapat dipilih per knowledge base
+var stemmers = map[string]Stemmer{
+	"id": stemIndonesian,
+	"en": stemEnglish,
+}
+
+// newStemFilter membuat tahap pipeline "stem" berdasarkan bahasa stemmer knowledge base
+func newStemFilter(kb *KnowledgeBase) (TextFilter, error) {
+	stem, ok := stemmers[kb.Stemmer]
+	if !ok {
+		return nil, fmt.Errorf("unknown stemmer %q (use \"id\" or \"en\")", kb.Stemmer)
+	}
+
+	return TextFilterFunc(func(tokens []string) []string {
+		result := make([]string, len(tokens))
+		for i, token := range tokens {
+			result[i] = stem(token)
+		}
+		return result
+	}), nil
+}
+
+// minStemLength adalah panjang minimum kata dasar hasil stemming bahasa Indonesia
+const minStemLength = 4
+
+// indonesianRoots adalah kata dasar umum yang bentuknya menyerupai kata berimbuhan
+// dan tidak boleh dipotong tanpa bantuan kamus
+var indonesianRoots = map[string]bool{
+	"sekolah": true, "masalah": true, "langkah": true, "dengan": true, "kalian": true,
+	"sekarang": true, "selamat": true, "sedang": true, "senang": true, "sendiri": true,
+	"seperti": true, "semangat": true, "sepeda": true, "selalu": true, "sering": true,
+	"mereka": true, "memang": true, "kemarin": true, "kenapa": true, "kemana": true,
+	"pertama": true, "perempuan": true, "kemudian": true, "penting": true, "dimana": true,
+	"peserta": true,
+}
+
+// kRoots adalah kata dasar umum berawalan k yang hurufnya luluh setelah meng-/peng-
+// (mengirim, pengiriman). Tanpa kamus, meng- sebelum vokal tidak dapat dibedakan
+// antara kata dasar berawalan vokal (mengambil) dan berawalan k, sehingga k hanya
+// dikembalikan untuk kata dasar di daftar ini.
+var kRoots = []string{
+	"kabar", "kaji", "kalah", "kelola", "kembali", "kembang", "kemas", "kenal", "kenang",
+	"kejar", "keluar", "kerja", "kering", "ketik", "kira", "kirim", "koreksi", "konfirmasi",
+	"kontrol", "kosong", "kotor", "kuasa", "kuat", "kumpul", "kunci", "kurang", "kutip",
+}
+
+// hasKRoot mengecek apakah kata diawali salah satu kRoots, sehingga awalan ke-
+// tidak dipotong lagi (kembang-kan, bukan ke-mbang-kan)
+func hasKRoot(word string) bool {
+	for _, root := range kRoots {
+		if strings.HasPrefix(word, root) {
+			return true
+		}
+	}
+	return false
+}
+
+// stemCandidate adalah salah satu kemungkinan kata dasar beserta jumlah imbuhan
+// yang dihapus, jumlah awalan di antaranya, awalan terluar, dan akhiran terakhir
+// yang dihapus
+type stemCandidate struct {
+	stem    string
+	affixes int
+	depth   int
+	prefix  string
+	suffix  string
+}
+
+// invalidConfixes adalah pasangan awalan terluar dan akhiran turunan yang tidak
+// pernah muncul bersama (Nazief-Adriani). Akhiran -i juga tidak dihapus tanpa
+// awalan karena tidak dapat dibedakan dari kata dasar (beli, cari, pergi).
+var invalidConfixes = map[[2]string]bool{
+	{"be", "i"}: true, {"di", "an"}: true, {"ke", "i"}: true, {"ke", "kan"}: true,
+	{"me", "an"}: true, {"se", "i"}: true, {"se", "kan"}: true, {"te", "an"}: true,
+	{"pe", "kan"}: true, {"pe", "i"}: true, {"", "i"}: true,
+}
+
+// stemIndonesian mencari kata dasar bahasa Indonesia dengan pendekatan
+// Nazief-Adriani tanpa kamus: partikel (-lah, -kah, -tah, -pun), kata ganti
+// kepemilikan (-ku, -mu, -nya), akhiran turunan (-kan, -an, -i), dan hingga tiga
+// awalan dihapus. Karena tidak ada kamus untuk memeriksa hasil, setiap kombinasi
+// pemotongan dicoba dan kandidat dengan imbuhan terbanyak dipilih, dengan awalan
+// lebih diutamakan daripada akhiran (ber-jalan, bukan berjal-an). Kandidat dengan
+// pasangan awalan dan akhiran yang tidak valid (pen-didi-kan) diabaikan.
+func stemIndonesian(word string) string {
+	if len([]rune(word)) <= minStemLength || indonesianRoots[word] {
+		return word
+	}
+
+	best := stemCandidate{stem: word}
+	consider := func(candidate stemCandidate) {
+		if invalidConfixes[[2]string{candidate.prefix, candidate.suffix}] {
+			return
+		}
+		if candidate.affixes > best.affixes ||
+			(candidate.affixes == best.affixes && candidate.depth > best.depth) {
+			best = candidate
+		}
+	}
+
+	derivational := []string{"kan", "an", "i"}
+	for _, inflected := range suffixVariants(stemCandidate{stem: word}, []string{"lah", "kah", "tah", "pun"}, []string{"nya", "ku", "mu"}) {
+		if indonesianRoots[inflected.stem] {
+			consider(inflected)
+			continue
+		}
+
+		// Awalan lebih dulu, lalu akhiran turunan
+		prefixed := removePrefixes(inflected)
+		for _, derived := range suffixVariants(prefixed, derivational) {
+			consider(derived)
+		}
+
+		// Urutan Nazief-Adriani: akhiran turunan lalu awalan
+		for _, derived := range suffixVariants(inflected, derivational) {
+			consider(removePrefixes(derived))
+		}
+	}
+
+	return best.stem
+}
+
+// suffixVariants menghasilkan kandidat tanpa pemotongan dan dengan setiap akhiran
+// yang cocok dihapus. Setiap grup akhiran dicoba secara berurutan.
+func suffixVariants(candidate stemCandidate, groups ...[]string) []stemCandidate {
+	variants := []stemCandidate{candidate}
+	for _, suffixes := range groups {
+		for _, variant := range variants {
+			for _, suffix := range suffixes {
+				if stem := removeSuffix(variant, suffix); stem != variant.stem {
+					variants = append(variants, stemCandidate{
+						stem:    stem,
+						affixes: variant.affixes + 1,
+						depth:   variant.depth,
+						prefix:  variant.prefix,
+						suffix:  suffix,
+					})
+				}
+			}
+		}
+	}
+	return variants
+}
+
+// removePrefixes menghapus hingga tiga awalan dari kandidat dan mencatat awalan
+// terluarnya
+func removePrefixes(candidate stemCandidate) stemCandidate {
+	for i := 0; i < 3 && !indonesianRoots[candidate.stem] && !hasKRoot(candidate.stem); i++ {
+		next := removePrefix(candidate.stem)
+		if next == candidate.stem {
+			break
+		}
+		if i == 0 {
+			candidate.prefix = prefixKind(candidate.stem)
+		} else if !isInnerPrefix(candidate, next) {
+			break
+		}
+		candidate.stem = next
+		candidate.affixes++
+		candidate.depth++
+	}
+	return candidate
+}
+
+// prefixKind mengelompokkan awalan terluar untuk pemeriksaan invalidConfixes.
+// per- dibedakan dari peN- karena per-...-kan valid (perdengarkan).
+func prefixKind(word string) string {
+	if strings.HasPrefix(word, "per") {
+		return "per"
+	}
+	return word[:2]
+}
+
+// isInnerPrefix mengecek apakah awalan kedua atau ketiga boleh dihapus. di- dan
+// se- hanya dapat menjadi awalan terluar (pen-didik-an, menye-lesai-kan), dan sisa
+// kata setelah akhiran turunan yang belum dihapus harus tetap cukup panjang
+// (ke-bersih-an, bukan ke-ber-sih-an).
+func isInnerPrefix(candidate stemCandidate, next string) bool {
+	if hasPrefix(candidate.stem, "di") || hasPrefix(candidate.stem, "se") {
+		return false
+	}
+
+	switch candidate.suffix {
+	case "kan", "an", "i":
+		// Akhiran turunan sudah dihapus
+	default:
+		if stem, found := strings.CutSuffix(next, "kan"); found {
+			next = stem
+		} else if stem, found := strings.CutSuffix(next, "an"); found {
+			next = stem
+		}
+	}
+	return len([]rune(next)) >= minStemLength
+}
+
+// removeSuffix menghapus akhiran jika kata dasar tetap cukup panjang. Akhiran -i
+// hanya dihapus setelah u atau satu konsonan penutup (perbaik-i, ketahu-i) karena
+// kata dasar berakhiran -ai atau gugus konsonan (pakai, ganti) tidak dapat
+// dibedakan tanpa kamus.
+func removeSuffix(candidate stemCandidate, suffix string) string {
+	stem, found := strings.CutSuffix(candidate.stem, suffix)
+	minLength := minStemLength
+	if candidate.prefix == "ke" && suffix == "an" {
+		// Konfiks ke-...-an dapat membungkus kata dasar pendek (ke-ada-an)
+		minLength = 3
+	}
+	if !found || len([]rune(stem)) < minLength {
+		return candidate.stem
+	}
+	if suffix == "i" && !endsBeforeSuffixI(stem) {
+		return candidate.stem
+	}
+	return stem
+}
+
+// endsBeforeSuffixI mengecek apakah kata dasar berakhir dengan u, atau dengan satu
+// konsonan setelah vokal (termasuk ng) sehingga -i dapat dianggap akhiran
+func endsBeforeSuffixI(stem string) bool {
+	last := stem[len(stem)-1]
+	if last == 'u' {
+		return true
+	}
+	if isVowel(last) {
+		return false
+	}
+	return isVowel(stem[len(stem)-2]) || strings.HasSuffix(stem, "ng")
+}
+
+// isVowel mengecek apakah byte merupakan huruf vokal
+func isVowel(b byte) bool {
+	return strings.IndexByte("aiueo", b) >= 0
+}
+
+// removePrefix menghapus satu awalan beserta aturan peluluhannya
+// (misalnya menulis menjadi tulis, memukul menjadi pukul, menyapu menjadi sapu,
+// mengirim menjadi kirim)
+func removePrefix(word string) string {
+	// meng-/peng- sebelum vokal meluluhkan k (meng-kirim menjadi mengirim)
+	if (hasPrefix(word, "meng") || hasPrefix(word, "peng")) && isVowel(word[4]) && hasKRoot("k"+word[4:]) {
+		return "k" + word[4:]
+	}
+
+	// menge-/penge- sebelum bentuk terikat seperti ketahu (penge-tahu-an,
+	// menge-tahu-i). Bila sisanya tidak diawali konsonan dan vokal (mengembangkan),
+	// aturan meng-/peng- berlaku.
+	if (hasPrefix(word, "menge") || hasPrefix(word, "penge")) && len(word) > 6 &&
+		!isVowel(word[5]) && isVowel(word[6]) && len([]rune(word[5:])) >= minStemLength {
+		return word[5:]
+	}
+
+	candidate := word
+	switch {
+	case hasPrefix(word, "di"), hasPrefix(word, "ke"), hasPrefix(word, "se"):
+		candidate = word[2:]
+
+	case hasPrefix(word, "meng"), hasPrefix(word, "peng"):
+		candidate = word[4:]
+	case hasPrefix(word, "meny"), hasPrefix(word, "peny"):
+		if isVowel(word[4]) {
+			candidate = "s" + word[4:]
+		}
+	case hasPrefix(word, "mem"), hasPrefix(word, "pem"):
+		if isVowel(word[3]) {
+			candidate = "p" + word[3:]
+		} else if strings.IndexByte("bfpv", word[3]) >= 0 {
+			candidate = word[3:]
+		}
+	case hasPrefix(word, "men"), hasPrefix(word, "pen"):
+		if isVowel(word[3]) {
+			candidate = "t" + word[3:]
+		} else if strings.IndexByte("cdjz", word[3]) >= 0 {
+			candidate = word[3:]
+		}
+	case strings.HasPrefix(word, "belajar"), strings.HasPrefix(word, "pelajar"):
+		candidate = word[3:]
+	case hasPrefix(word, "ber"), hasPrefix(word, "ter"), hasPrefix(word, "per"):
+		candidate = word[3:]
+	case hasPrefix(word, "me"), hasPrefix(word, "pe"):
+		if strings.IndexByte("lrwymn", word[2]) >= 0 {
+			candidate = word[2:]
+		} else if word[0] == 'p' && len(word) > 4 && !isVowel(word[2]) && word[3:5] == "er" {
+			// pe-kerja seperti be-kerja: awalan pe- sebelum suku kata -er
+			candidate = word[2:]
+		}
+	case hasPrefix(word, "be"), hasPrefix(word, "te"):
+		// be-kerja, te-percaya: awalan be-/te- sebelum suku kata -er
+		if len(word) > 4 && !isVowel(word[2]) && word[3:5] == "er" {
+			candidate = word[2:]
+		}
+	}
+
+	if len([]rune(candidate)) < minStemLength {
+		return word
+	}
+	return candidate
+}
+
+// hasPrefix mengecek awalan dan memastikan masih ada huruf setelah awalan
+func hasPrefix(word, prefix string) bool {
+	return len(word) > len(prefix) && strings.HasPrefix(word, prefix)
+}
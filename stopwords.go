@@ -0,0 +1,77 @@
+package beo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stopwords merepresentasikan konfigurasi stopword knowledge base
+type Stopwords struct {
+	Languages []string `yaml:"languages,omitempty"`
+	Words     []string `yaml:"words,omitempty"`
+	Keep      []string `yaml:"keep,omitempty"`
+}
+
+// enabled mengecek apakah ada stopword yang dikonfigurasi
+func (s Stopwords) enabled() bool {
+	return len(s.Languages) > 0 || len(s.Words) > 0
+}
+
+// stopwordLists adalah daftar stopword bawaan per bahasa. Kata tanya yang
+// membedakan maksud (where, when, who, why, how, dimana, kapan, siapa,
+// mengapa, bagaimana) sengaja tidak dimasukkan.
+var stopwordLists = map[string]string{
+	"en": `a about above after again all am an and any are as at be because been
+		before being below between both but by can could did do does doing down during
+		each few for from further had has have having he her here hers herself him
+		himself his i if in into is it its itself just me more most my myself no nor
+		not of off on once only or other our ours ourselves out over own same she
+		should so some such than that the their theirs them themselves then there
+		these they this those through to too under until up very was we were what
+		which while whom will with would you your yours yourself yourselves please`,
+	"id": `ada adalah agak agar akan aku anda apa apakah atau bagi bahwa beberapa
+		begitu belum bila boleh bukan dalam dan dapat dari daripada demikian dengan
+		di dia hal harus hanya ia ialah ini itu jadi jika juga kah kalau kami kamu
+		karena ke kepada kita lagi lah maka mau mereka nya oleh pada para per pun
+		saja saya sebagai sebuah sedang sehingga sekali seorang seperti serta sesuatu
+		sih silakan suatu sudah supaya tapi telah tentang tersebut tetapi tolong untuk
+		yaitu yakni yang`,
+}
+
+// newStopwordFilter membuat tahap pipeline "stopwords" dari konfigurasi knowledge base
+func newStopwordFilter(kb *KnowledgeBase) (TextFilter, error) {
+	stopwords := map[string]bool{}
+	for _, language := range kb.Stopwords.Languages {
+		list, ok := stopwordLists[language]
+		if !ok {
+			return nil, fmt.Errorf("unknown stopword language %q (use \"id\" or \"en\")", language)
+		}
+		for _, word := range strings.Fields(list) {
+			stopwords[word] = true
+		}
+	}
+	for _, word := range kb.Stopwords.Words {
+		for _, token := range tokenize(word) {
+			stopwords[token] = true
+		}
+	}
+	for _, word := range kb.Stopwords.Keep {
+		for _, token := range tokenize(word) {
+			delete(stopwords, token)
+		}
+	}
+
+	return TextFilterFunc(func(tokens []string) []string {
+		result := make([]string, 0, len(tokens))
+		for _, token := range tokens {
+			if !stopwords[token] {
+				result = append(result, token)
+			}
+		}
+		// Teks yang seluruhnya stopword ("who are you") tetap dapat dicocokkan
+		if len(result) == 0 {
+			return tokens
+		}
+		return result
+	}), nil
+}
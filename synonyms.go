@@ -0,0 +1,113 @@
+package beo
+
+import (
+	"strings"
+)
+
+// SynonymMatch mencatat sebuah kata atau frasa input yang diganti dengan bentuk kanoniknya
+type SynonymMatch struct {
+	Phrase    string
+	Canonical string
+}
+
+// synonymMatcher mengganti kata atau frasa sinonim dengan bentuk kanonik grupnya
+type synonymMatcher struct {
+	phrases   map[string][]string
+	canonical map[string]string
+	maxLength int
+}
+
+// newSynonymMatcher menyusun pencocok sinonim. Anggota pertama setiap grup
+// menjadi bentuk kanonik yang dipakai untuk seluruh anggota grup.
+func newSynonymMatcher(groups [][]string) *synonymMatcher {
+	matcher := &synonymMatcher{
+		phrases:   make(map[string][]string),
+		canonical: make(map[string]string),
+	}
+
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		canonicalTokens := tokenize(group[0])
+		if len(canonicalTokens) == 0 {
+			continue
+		}
+
+		for _, member := range group {
+			tokens := tokenize(member)
+			if len(tokens) == 0 {
+				continue
+			}
+			key := strings.Join(tokens, " ")
+			matcher.phrases[key] = canonicalTokens
+			matcher.canonical[key] = strings.Join(canonicalTokens, " ")
+			matcher.maxLength = max(matcher.maxLength, len(tokens))
+		}
+	}
+	return matcher
+}
+
+// apply mengganti sinonim dalam token dengan mencocokkan frasa terpanjang
+// terlebih dahulu, lalu melaporkan sinonim yang diganti
+func (m *synonymMatcher) apply(tokens []string) ([]string, []SynonymMatch) {
+	var result []string
+	var matches []SynonymMatch
+
+	for i := 0; i < len(tokens); {
+		matched := false
+		for length := min(m.maxLength, len(tokens)-i); length > 0; length-- {
+			key := strings.Join(tokens[i:i+length], " ")
+			replacement, ok := m.phrases[key]
+			if !ok {
+				continue
+			}
+
+			result = append(result, replacement...)
+			if key != m.canonical[key] {
+				matches = append(matches, SynonymMatch{Phrase: key, Canonical: m.canonical[key]})
+			}
+			i += length
+			matched = true
+			break
+		}
+		if !matched {
+			result = append(result, tokens[i])
+			i++
+		}
+	}
+	return result, matches
+}
+
+// newSynonymFilter membuat tahap pipeline "synonyms" dari konfigurasi knowledge base
+func newSynonymFilter(kb *KnowledgeBase) (TextFilter, error) {
+	matcher := newSynonymMatcher(kb.Synonyms)
+	return TextFilterFunc(func(tokens []string) []string {
+		result, _ := matcher.apply(tokens)
+		return result
+	}), nil
+}
+
+// MatchedSynonyms mengembalikan sinonim dalam input yang akan diganti dengan
+// bentuk kanoniknya saat pencocokan
+func (ai *AI) MatchedSynonyms(input string) []SynonymMatch {
+	matcher := newSynonymMatcher(ai.KnowledgeBase.Synonyms)
+
+	var matches []SynonymMatch
+	for _, segment := range ai.KnowledgeBase.segment(input) {
+		_, segmentMatches := matcher.apply(tokenize(segment))
+		matches = append(matches, segmentMatches...)
+	}
+	return matches
+}
+
+// SetSynonyms mengganti grup sinonim dan membangun ulang indeks
+func (ai *AI) SetSynonyms(groups [][]string) error {
+	previous := ai.KnowledgeBase.Synonyms
+	ai.KnowledgeBase.Synonyms = groups
+	if err := ai.reindex(); err != nil {
+		ai.KnowledgeBase.Synonyms = previous
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package beo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Mode render jawaban
+const (
+	RenderPlaceholders = "placeholders"
+	RenderTemplate     = "template"
+)
+
+// TemplateData adalah data yang tersedia bagi jawaban dalam mode template
+type TemplateData struct {
+	Input    string
+	Question string
+	Slots    Slots
+	Session  map[string]string
+	Now      time.Time
+
+	ctx context.Context
+	ai  *AI
+}
+
+// Placeholder mengembalikan nilai placeholder dengan urutan prioritas yang sama
+// seperti %nama%, atau string kosong jika tidak ditemukan
+func (d TemplateData) Placeholder(name string) string {
+	value, _ := d.ai.lookupPlaceholder(d.ctx, name)
+	return value
+}
+
+// Date memformat waktu sekarang dengan argumen yang sama seperti %date:argumen%
+func (d TemplateData) Date(arg string) string {
+	value, _ := placeholderFunctions["date"](d.ctx, d.ai, arg)
+	return value
+}
+
+// templateFuncs adalah fungsi bantu yang tersedia dalam template jawaban
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"title": titleCase,
+	"trim":  strings.TrimSpace,
+	"join":  strings.Join,
+	"default": func(fallback, value string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	},
+	"choose": func(choices ...string) string {
+		return randomChoice(choices)
+	},
+	"isWeekend": func(t time.Time) bool {
+		return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+	},
+}
+
+// parseAnswerTemplate mem-parsing satu jawaban sebagai template
+func parseAnswerTemplate(answer string) (*template.Template, error) {
+	return template.New("answer").Funcs(templateFuncs).Option("missingkey=zero").Parse(answer)
+}
+
+// updateTemplates mem-parsing dan menyimpan template dari seluruh jawaban
+// jika mode template aktif. Template yang gagal di-parsing tidak disimpan
+// dan dilaporkan oleh Validate.
+func (kb *KnowledgeBase) updateTemplates() {
+	kb.templates = nil
+	if kb.Render != RenderTemplate {
+		return
+	}
+
+	kb.templates = make(map[string]*template.Template)
+	parse := func(answers []string) {
+		for _, answer := range answers {
+			if _, done := kb.templates[answer]; done {
+				continue
+			}
+			if tmpl, err := parseAnswerTemplate(answer); err == nil {
+				kb.templates[answer] = tmpl
+			}
+		}
+	}
+
+	for _, question := range kb.Questions {
+		parse(question.Answers)
+	}
+	for _, hook := range kb.Hooks {
+		parse(hook.Answers)
+	}
+}
+
+// renderAnswer menghasilkan teks akhir sebuah jawaban. Dalam mode template,
+// jawaban dieksekusi sebagai text/template terlebih dahulu, kemudian variasi
+// inline dipilih dan placeholder diproses.
+func (ai *AI) renderAnswer(ctx context.Context, answer, input string, question Question) (string, error) {
+	if ai.KnowledgeBase.Render == RenderTemplate {
+		tmpl, ok := ai.KnowledgeBase.templates[answer]
+		if !ok {
+			var err error
+			tmpl, err = parseAnswerTemplate(answer)
+			if err != nil {
+				return "", fmt.Errorf("gagal mem-parsing template jawaban: %w", err)
+			}
+		}
+
+		data := TemplateData{
+			Input:    input,
+			Question: question.Question,
+			Slots:    slotsFromContext(ctx),
+			Now:      currentTime(ai.KnowledgeBase.Formats),
+			ctx:      ctx,
+			ai:       ai,
+		}
+		if session := sessionFromContext(ctx); session != nil {
+			data.Session = session.Vars()
+		}
+
+		var builder strings.Builder
+		if err := tmpl.Execute(&builder, data); err != nil {
+			return "", fmt.Errorf("gagal menjalankan template jawaban: %w", err)
+		}
+		answer = builder.String()
+	}
+
+	return ai.processPlaceholders(ctx, expandVariations(answer))
+}
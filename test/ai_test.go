@@ -83,6 +83,36 @@ func TestTrain(t *testing.T) {
 	}
 }
 
+// Test fungsi RemoveQuestion untuk memastikan pertanyaan dapat dihapus dengan benar
+func TestRemoveQuestion(t *testing.T) {
+	file, err := os.CreateTemp("", "knowledgebase_test_*.yml")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	ai, err := beo.NewAI(file)
+	if err != nil {
+		t.Fatalf("Error initializing AI: %v", err)
+	}
+
+	ai.Train("What is the capital of France?", []string{"Paris"}, "")
+	ai.Train("What is the capital of Japan?", []string{"Tokyo"}, "")
+	if err := ai.RemoveQuestion("What is the capital of France?"); err != nil {
+		t.Fatalf("Error removing question: %v", err)
+	}
+
+	if len(ai.KnowledgeBase.Questions) != 1 {
+		t.Errorf("Expected 1 question, but got %d", len(ai.KnowledgeBase.Questions))
+	}
+	if answer := ai.Ask("What is the capital of France?"); answer == "Paris" {
+		t.Errorf("Expected removed question not to be answered, but got %v", answer)
+	}
+	if err := ai.RemoveQuestion("What is the capital of France?"); err == nil {
+		t.Errorf("Expected error when removing an unknown question")
+	}
+}
+
 // Test fungsi AddHook untuk memastikan hook dapat ditambahkan dengan benar
 func TestAddHook(t *testing.T) {
 	file, err := os.CreateTemp("", "knowledgebase_test_*.yml")
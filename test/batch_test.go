@@ -0,0 +1,62 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// Test AskBatch menjawab seluruh pertanyaan sesuai urutan input
+func TestAskBatch(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is your name?", []string{"Beo."}, "")
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+
+	var queries, expected []string
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			queries, expected = append(queries, fmt.Sprintf("what is your name %d", i)), append(expected, "Beo.")
+		} else {
+			queries, expected = append(queries, "where is the ofice"), append(expected, "Jakarta.")
+		}
+	}
+
+	results := ai.AskBatch(context.Background(), queries, 8)
+	if len(results) != len(queries) {
+		t.Fatalf("Expected %d results, but got %d", len(queries), len(results))
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			t.Fatalf("Unexpected error: %v", result.Err)
+		}
+		if result.Index != i || result.Query != queries[i] || result.Response.Text != expected[i] {
+			t.Errorf("Result %d expected %q for %q, but got %+v", i, expected[i], queries[i], result)
+		}
+	}
+
+	// ID pertanyaan dan skor tersedia per jawaban
+	answers := results[1].Response.Answers
+	if len(answers) != 1 || answers[0].ID != 2 || answers[0].Score <= 0 {
+		t.Errorf("Expected question ID 2 with a score, but got %+v", answers)
+	}
+}
+
+// Test AskBatch mengembalikan error ctx jika dibatalkan
+func TestAskBatchCanceled(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is your name?", []string{"Beo."}, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := ai.AskBatch(ctx, []string{"what is your name", "name"}, 0)
+	for _, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, but got %v", result.Err)
+		}
+	}
+	if results := ai.AskBatch(context.Background(), nil, 4); len(results) != 0 {
+		t.Errorf("Expected no results, but got %+v", results)
+	}
+}
@@ -0,0 +1,156 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// newClarifyAI membuat AI dengan dua pertanyaan yang sama-sama cocok untuk "reset"
+func newClarifyAI(t *testing.T) *beo.AI {
+	ai := newTestAI(t)
+	ai.Train("reset password", []string{"Use the forgot password link."}, "")
+	ai.Train("reset account", []string{"Contact support to reset your account."}, "")
+	ai.Train("opening hours", []string{"We are open 9 to 5."}, "")
+	if err := ai.SetClarification(beo.Clarification{Margin: 0.05}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return ai
+}
+
+// Test klarifikasi ditampilkan jika kandidat teratas memiliki skor hampir sama
+func TestClarification(t *testing.T) {
+	ai := newClarifyAI(t)
+
+	response, err := ai.Respond(context.Background(), "reset")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(response.Candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, but got %q", response.Candidates)
+	}
+	if !strings.HasPrefix(response.Text, "Did you mean:") {
+		t.Errorf("Expected clarification, but got %q", response.Text)
+	}
+
+	// Pertanyaan yang tidak ambigu tetap dijawab langsung
+	if answer := ai.Ask("opening hours"); answer != "We are open 9 to 5." {
+		t.Errorf("Expected direct answer, but got %q", answer)
+	}
+}
+
+// Test klarifikasi tidak aktif tanpa margin
+func TestClarificationDisabled(t *testing.T) {
+	ai := newClarifyAI(t)
+	if err := ai.SetClarification(beo.Clarification{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	response, err := ai.Respond(context.Background(), "reset")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(response.Candidates) != 0 || strings.HasPrefix(response.Text, "Did you mean") {
+		t.Errorf("Expected a direct answer, but got %+v", response)
+	}
+}
+
+// Test jawaban lanjutan dalam sesi memilih kandidat klarifikasi
+func TestClarificationFollowUp(t *testing.T) {
+	ai := newClarifyAI(t)
+
+	tests := []struct {
+		followUp string
+		expected string
+	}{
+		{"the first one", "Use the forgot password link."},
+		{"yang kedua", "Contact support to reset your account."},
+		{"2", "Contact support to reset your account."},
+		{"number 2 please", "Contact support to reset your account."},
+		{"last", "Contact support to reset your account."},
+		{"Reset account", "Contact support to reset your account."},
+	}
+	for _, test := range tests {
+		ctx := beo.WithSession(context.Background(), beo.NewSession())
+
+		response, err := ai.Respond(ctx, "reset")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if strings.Join(response.Candidates, ", ") != "reset password, reset account" {
+			t.Fatalf("Unexpected candidates %q", response.Candidates)
+		}
+
+		answer, err := ai.AskContext(ctx, test.followUp)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.followUp, test.expected, answer)
+		}
+	}
+}
+
+// Test pertanyaan baru yang mengandung angka atau kata urutan tidak dianggap pilihan
+func TestClarificationFollowUpNewQuestion(t *testing.T) {
+	ai := newClarifyAI(t)
+
+	for _, input := range []string{
+		"what are your opening hours on day 1",
+		"what is the first opening hours",
+		"1 or 2",
+	} {
+		ctx := beo.WithSession(context.Background(), beo.NewSession())
+		if _, err := ai.AskContext(ctx, "reset"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := "We are open 9 to 5."
+		if input == "1 or 2" {
+			expected = ai.KnowledgeBase.Fallbacks.NoAnswer
+		}
+		if answer, _ := ai.AskContext(ctx, input); answer != expected {
+			t.Errorf("For %q expected %q, but got %q", input, expected, answer)
+		}
+	}
+}
+
+// Test pilihan hanya berlaku untuk satu jawaban lanjutan
+func TestClarificationFollowUpOnce(t *testing.T) {
+	ai := newClarifyAI(t)
+	ctx := beo.WithSession(context.Background(), beo.NewSession())
+
+	if _, err := ai.AskContext(ctx, "reset"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if answer, _ := ai.AskContext(ctx, "opening hours"); answer != "We are open 9 to 5." {
+		t.Errorf("Expected new question to be answered, but got %q", answer)
+	}
+	if answer, _ := ai.AskContext(ctx, "1"); answer != ai.KnowledgeBase.Fallbacks.NoAnswer {
+		t.Errorf("Expected no pending clarification, but got %q", answer)
+	}
+}
+
+// Test template klarifikasi dapat dikonfigurasi dan divalidasi
+func TestClarificationTemplate(t *testing.T) {
+	ai := newClarifyAI(t)
+
+	err := ai.SetClarification(beo.Clarification{
+		Margin:   0.05,
+		Template: `Maksud Anda{{range .Candidates}} [{{.Number}}] {{.Question}}{{end}}?`,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	answer := ai.Ask("reset")
+	if !strings.HasPrefix(answer, "Maksud Anda [1] reset ") || !strings.Contains(answer, "[2] reset ") {
+		t.Errorf("Unexpected clarification %q", answer)
+	}
+
+	if err := ai.SetClarification(beo.Clarification{Margin: 0.05, Template: "{{.Candidates"}); err == nil {
+		t.Error("Expected error for invalid template")
+	}
+}
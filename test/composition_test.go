@@ -0,0 +1,92 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// newCompositionAI membuat AI dengan beberapa pertanyaan untuk input multi-kalimat
+func newCompositionAI(t *testing.T) *beo.AI {
+	ai := newTestAI(t)
+	ai.Train("What is your name?", []string{"I am Beo."}, "")
+	ai.Train("How old are you?", []string{"Three years."}, "")
+	ai.Train("Where do you live?", []string{"In Go."}, "")
+	return ai
+}
+
+// Test pilihan format, pemisah, dan penghapusan duplikat
+func TestComposition(t *testing.T) {
+	ai := newCompositionAI(t)
+	input := "What is your name? How old are you? What is your name?"
+
+	tests := []struct {
+		composition beo.Composition
+		input       string
+		expected    string
+	}{
+		{beo.Composition{}, input, "I am Beo. Three years. I am Beo."},
+		{beo.Composition{Dedupe: true}, input, "I am Beo. Three years."},
+		{beo.Composition{Dedupe: true, Format: beo.ComposeNumbered}, input, "1. I am Beo.\n2. Three years."},
+		{beo.Composition{Dedupe: true, Format: beo.ComposeBullets}, input, "- I am Beo.\n- Three years."},
+		{beo.Composition{Dedupe: true, Separator: " | "}, input, "I am Beo. | Three years."},
+		{beo.Composition{MaxAnswers: 2}, "Where do you live? How old are you? What is your name?", "In Go. Three years."},
+		{
+			beo.Composition{Dedupe: true, Template: `{{range $i, $a := .Answers}}{{if $i}}; {{end}}{{$a.Input}}: {{$a.Text}}{{end}}`},
+			input,
+			"What is your name: I am Beo.; How old are you: Three years.",
+		},
+	}
+	for _, test := range tests {
+		if err := ai.SetComposition(test.composition); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("With %+v for %q expected %q, but got %q", test.composition, test.input, test.expected, answer)
+		}
+	}
+}
+
+// Test daftar jawaban terstruktur untuk pemanggil API
+func TestCompositionAnswers(t *testing.T) {
+	ai := newCompositionAI(t)
+
+	response, err := ai.Respond(context.Background(), "Where do you live? What is your name?")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(response.Answers) != 2 {
+		t.Fatalf("Expected 2 answers, but got %+v", response.Answers)
+	}
+
+	expected := []beo.Answer{
+		{Segment: 0, Input: "Where do you live", Question: "Where do you live?", Text: "In Go."},
+		{Segment: 1, Input: "What is your name", Question: "What is your name?", Text: "I am Beo."},
+	}
+	for i, answer := range response.Answers {
+		if answer.Segment != expected[i].Segment || answer.Input != expected[i].Input ||
+			answer.Question != expected[i].Question || answer.Text != expected[i].Text {
+			t.Errorf("Answer %d expected %+v, but got %+v", i, expected[i], answer)
+		}
+		if answer.Score <= 0 || answer.Score > 1.0000001 {
+			t.Errorf("Answer %d has invalid score %v", i, answer.Score)
+		}
+	}
+}
+
+// Test konfigurasi komposisi yang tidak valid ditolak
+func TestCompositionInvalid(t *testing.T) {
+	ai := newCompositionAI(t)
+
+	for _, composition := range []beo.Composition{
+		{Format: "table"},
+		{MaxAnswers: -1},
+		{Template: "{{range .Answers}"},
+	} {
+		if err := ai.SetComposition(composition); err == nil {
+			t.Errorf("Expected error for %+v", composition)
+		}
+	}
+}
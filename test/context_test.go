@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Ismananda/beo"
+)
+
+// newTestAI membuat AI baru dengan file knowledge base sementara
+func newTestAI(t testing.TB) *beo.AI {
+	t.Helper()
+
+	file, err := os.CreateTemp("", "knowledgebase_test_*.yml")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	t.Cleanup(func() {
+		file.Close()
+		os.Remove(file.Name())
+	})
+
+	ai, err := beo.NewAI(file)
+	if err != nil {
+		t.Fatalf("Error initializing AI: %v", err)
+	}
+	return ai
+}
+
+// Test AskContext mengembalikan error jika context sudah dibatalkan
+func TestAskContextCanceled(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is your name?", []string{"My name is TestBot."}, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ai.AskContext(ctx, "What is your name?")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+// Test AskContext berhenti menunggu hook dinamis yang lambat ketika batas waktu habis
+func TestAskContextHookTimeout(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("How is the weather?", nil, "weather")
+	ai.AddHookFunc("weather", func(ctx context.Context, input string) (string, error) {
+		time.Sleep(time.Second)
+		return "Sunny", nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := ai.AskContext(ctx, "How is the weather?")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("AskContext did not return promptly after timeout")
+	}
+}
+
+// Test hook dinamis digunakan sebagai jawaban
+func TestAddHookFunc(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("How is the weather?", nil, "weather")
+	ai.AddHookFunc("weather", func(ctx context.Context, input string) (string, error) {
+		return "Sunny", nil
+	})
+
+	answer, err := ai.AskContext(context.Background(), "How is the weather?")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if answer != "Sunny" {
+		t.Errorf("Expected answer Sunny, but got %v", answer)
+	}
+}
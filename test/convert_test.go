@@ -0,0 +1,219 @@
+package test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// hasIssue melaporkan apakah laporan konversi berisi pesan yang mengandung teks
+func hasIssue(report beo.ConversionReport, text string) bool {
+	for _, issue := range report.Issues {
+		if strings.Contains(issue.Location+" "+issue.Message, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// Test ConvertAIML mengonversi pola, random, topic, dan srai
+func TestConvertAIML(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<aiml version="2.0">
+  <category>
+    <pattern>HELLO</pattern>
+    <template>
+      <random><li>Hi there!</li><li>Hello, I am <bot name="name"/>.</li></random>
+    </template>
+  </category>
+  <category><pattern>HI</pattern><template><srai>HELLO</srai></template></category>
+  <category><pattern>HEY</pattern><template><srai>HI</srai></template></category>
+  <category><pattern>BYE</pattern><template><srai>FAREWELL</srai></template></category>
+  <category><pattern>*</pattern><template>Sorry?</template></category>
+  <category><pattern>WHAT IS YOUR AGE</pattern><template>Young.<br/>Very <uppercase>young</uppercase>.</template></category>
+  <topic name="PETS">
+    <category><pattern>DO YOU HAVE A CAT</pattern><template><think><set name="pet">cat</set></think>No.</template></category>
+  </topic>
+</aiml>`
+
+	kb, report, err := beo.ConvertAIML(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []beo.Question{
+		{Question: "Hello", Hook: "hello"},
+		{Question: "Hi", Hook: "hello"},
+		{Question: "Hey", Hook: "hello"},
+		{Question: "What is your age", Answers: []string{"Young.\nVery YOUNG."}},
+		{Question: "Do you have a cat", Answers: []string{"No."}, Tags: []string{"pets"}},
+	}
+	if !reflect.DeepEqual(kb.Questions, expected) {
+		t.Errorf("Expected questions %+v, but got %+v", expected, kb.Questions)
+	}
+	hook := []string{"Hi there!", "Hello, I am %ainame%."}
+	if !reflect.DeepEqual(kb.Hooks["hello"].Answers, hook) {
+		t.Errorf("Expected hook answers %q, but got %q", hook, kb.Hooks["hello"].Answers)
+	}
+
+	if report.Converted != 5 || report.Skipped != 2 {
+		t.Errorf("Expected 5 converted and 2 skipped, but got %v", report)
+	}
+	for _, text := range []string{`"FAREWELL" was not found`, "no words left", "<think> is not supported"} {
+		if !hasIssue(report, text) {
+			t.Errorf("Expected an issue containing %q, but got %+v", text, report.Issues)
+		}
+	}
+
+	if _, _, err := beo.ConvertAIML(strings.NewReader("<html></html>")); err == nil {
+		t.Error("Expected an error for a document without <aiml>")
+	}
+}
+
+// Test ConvertChatterBot mengubah setiap giliran percakapan menjadi pasangan tanya jawab
+func TestConvertChatterBot(t *testing.T) {
+	input := `categories:
+- Greetings
+conversations:
+- - Good morning
+  - Good morning to you.
+- - How are you?
+  - I am fine.
+  - Glad to hear that.
+- - Good morning
+  - Morning!
+- - Alone
+`
+	kb, report, err := beo.ConvertChatterBot(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []beo.Question{
+		{Question: "Good morning", Answers: []string{"Good morning to you.", "Morning!"}, Tags: []string{"greetings"}},
+		{Question: "How are you?", Answers: []string{"I am fine."}, Tags: []string{"greetings"}},
+		{Question: "I am fine.", Answers: []string{"Glad to hear that."}, Tags: []string{"greetings"}},
+	}
+	if !reflect.DeepEqual(kb.Questions, expected) {
+		t.Errorf("Expected questions %+v, but got %+v", expected, kb.Questions)
+	}
+	if report.Skipped != 1 || !hasIssue(report, "3 turns") || !hasIssue(report, "no response") {
+		t.Errorf("Expected one skipped conversation and a multi-turn issue, but got %+v", report)
+	}
+}
+
+// Test ConvertRasa memakai contoh intent sebagai alias dan response dari rules
+func TestConvertRasa(t *testing.T) {
+	nlu := `version: "3.1"
+nlu:
+- intent: greet
+  examples: |
+    - hey
+    - hello there
+- intent: ask_weather
+  examples: |
+    - what's the weather in [Jakarta](city)
+    - will it rain today
+- intent: check_balance
+  examples: |
+    - what is my balance
+- synonym: credit
+  examples: |
+    - credit card
+- regex: account_number
+  examples: |
+    - \d{10}
+`
+	domain := `responses:
+  utter_greet:
+  - text: Hi!
+  utter_forecast:
+  - text: It is sunny.
+    image: sun.png
+`
+	rules := `rules:
+- rule: weather
+  steps:
+  - intent: ask_weather
+  - action: utter_forecast
+`
+
+	kb, report, err := beo.ConvertRasa(strings.NewReader(nlu), strings.NewReader(domain), strings.NewReader(rules))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []beo.Question{
+		{Question: "hey", Aliases: []string{"hello there"}, Answers: []string{"Hi!"}},
+		{Question: "what's the weather in Jakarta", Aliases: []string{"will it rain today"}, Answers: []string{"It is sunny."}},
+		{Question: "what is my balance", Hook: "check_balance"},
+	}
+	if !reflect.DeepEqual(kb.Questions, expected) {
+		t.Errorf("Expected questions %+v, but got %+v", expected, kb.Questions)
+	}
+	if !reflect.DeepEqual(kb.Synonyms, [][]string{{"credit", "credit card"}}) {
+		t.Errorf("Expected credit synonym group, but got %q", kb.Synonyms)
+	}
+	for _, text := range []string{"entity annotations", `"image"`, "no response", "regex is not supported"} {
+		if !hasIssue(report, text) {
+			t.Errorf("Expected an issue containing %q, but got %+v", text, report.Issues)
+		}
+	}
+}
+
+// Test pertanyaan dengan alias dapat dijawab melalui aliasnya
+func TestQuestionAliases(t *testing.T) {
+	ai := newTestAI(t)
+	_, err := ai.Import([]beo.Question{
+		{Question: "What is the weather?", Aliases: []string{"will it rain today"}, Answers: []string{"Sunny."}},
+		{Question: "What is my balance?", Answers: []string{"100."}},
+		{Question: "Where is the office?", Answers: []string{"Jakarta."}},
+	}, beo.ImportMerge, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if answer := ai.Ask("will it rain today"); answer != "Sunny." {
+		t.Errorf("Expected %q via alias, but got %q", "Sunny.", answer)
+	}
+
+	explanation, err := ai.Explain(context.Background(), "will it rain today")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(explanation.Segments) == 0 || len(explanation.Segments[0].Matches) == 0 {
+		t.Fatalf("Expected a match, but got %+v", explanation)
+	}
+	if alias := explanation.Segments[0].Matches[0].Alias; alias != "will it rain today" {
+		t.Errorf("Expected matched alias to be reported, but got %q", alias)
+	}
+}
+
+// Test kata yang dipakai di banyak alias satu pertanyaan tetap berbobot, karena
+// IDF dihitung per pertanyaan dan bukan per alias
+func TestQuestionManyAliases(t *testing.T) {
+	ai := newTestAI(t)
+	_, err := ai.Import([]beo.Question{
+		{
+			Question: "What is the weather today",
+			Aliases: []string{
+				"weather forecast", "weather tomorrow", "weather in jakarta", "weather report",
+				"weather this weekend", "weather outside", "weather now", "weather update",
+				"weather alert", "weather tonight",
+			},
+			Answers: []string{"Sunny."},
+		},
+		{Question: "What is my balance?", Answers: []string{"100."}},
+		{Question: "Where is the office?", Answers: []string{"Jakarta."}},
+	}, beo.ImportMerge, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if answer := ai.Ask("weather"); answer != "Sunny." {
+		t.Errorf("For %q expected %q, but got %q", "weather", "Sunny.", answer)
+	}
+}
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// randomWord membuat kata acak huruf kecil dengan panjang 4 sampai 9 huruf
+func randomWord(random *rand.Rand) string {
+	letters := make([]byte, 4+random.Intn(6))
+	for i := range letters {
+		letters[i] = byte('a' + random.Intn(26))
+	}
+	return string(letters)
+}
+
+// newBenchmarkAI membuat AI dengan kosakata besar dan mengembalikan input bertypo
+func newBenchmarkAI(b *testing.B, questions int) (*beo.AI, []string) {
+	b.Helper()
+
+	random := rand.New(rand.NewSource(1))
+	ai := newTestAI(b)
+	for i := 0; i < questions; i++ {
+		words := make([]string, 6)
+		for j := range words {
+			words[j] = randomWord(random)
+		}
+		ai.KnowledgeBase.Questions = append(ai.KnowledgeBase.Questions, beo.Question{
+			Question: strings.Join(words, " "),
+			Answers:  []string{"Answer"},
+		})
+	}
+	ai.Train("What is your name?", []string{"Beo"}, "")
+
+	var inputs []string
+	for _, question := range ai.KnowledgeBase.Questions[:50] {
+		words := strings.Fields(question.Question)
+		for i, word := range words[:3] {
+			// Ubah satu huruf untuk membuat typo
+			letters := []byte(word)
+			letters[len(letters)/2] = byte('a' + (int(letters[len(letters)/2]-'a')+1)%26)
+			words[i] = string(letters)
+		}
+		inputs = append(inputs, strings.Join(words[:3], " "))
+	}
+	return ai, inputs
+}
+
+// Benchmark Ask dengan koreksi typo pada kosakata besar
+func BenchmarkAskTypoLargeVocabulary(b *testing.B) {
+	ai, inputs := newBenchmarkAI(b, 2000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ai.Ask(inputs[i%len(inputs)])
+	}
+}
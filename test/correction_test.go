@@ -0,0 +1,145 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test koreksi typo hingga dua huruf pada input
+func TestTypoCorrection(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is the capital of France?", []string{"Paris"}, "")
+	ai.Train("Who painted the Mona Lisa?", []string{"Leonardo da Vinci"}, "")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"capitl of frnace", "Paris"},
+		{"who paintd the mona lsia", "Leonardo da Vinci"},
+		{"Wht is the captal of Frence?", "Paris"},
+	}
+	for _, test := range tests {
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, answer)
+		}
+	}
+}
+
+// Test jarak edit dihitung per rune untuk huruf non-Latin
+func TestTypoCorrectionRunes(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("東京タワー", []string{"Tokyo Tower"}, "")
+	ai.Train("富士山", []string{"Mount Fuji"}, "")
+
+	if answer := ai.Ask("東京タワ"); answer != "Tokyo Tower" {
+		t.Errorf("Expected Tokyo Tower, but got %q", answer)
+	}
+}
+
+// Test pertukaran huruf dan jarak keyboard memengaruhi pilihan koreksi
+func TestTypoCorrectionDistance(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("receive", []string{"Receive"}, "")
+	ai.Train("relieve", []string{"Relieve"}, "")
+	ai.Train("cat", []string{"Animal"}, "")
+	ai.Train("cut", []string{"Action"}, "")
+
+	tests := []struct {
+		matching beo.Matching
+		input    string
+		expected string
+	}{
+		{beo.Matching{}, "recieve", "Relieve"},
+		{beo.Matching{Distance: beo.DistanceDamerau}, "recieve", "Receive"},
+		{beo.Matching{}, "cit", "Animal"},
+		{beo.Matching{Keyboard: true}, "cit", "Action"},
+	}
+	for _, test := range tests {
+		if err := ai.SetMatching(test.matching); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("With %+v for %q expected %q, but got %q", test.matching, test.input, test.expected, answer)
+		}
+	}
+}
+
+// Test kata pendek yang valid tidak dikoreksi menjadi kata lain
+func TestTypoCorrectionShortWords(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("it", []string{"Pronoun"}, "")
+
+	if answer := ai.Ask("is"); answer != "I'm sorry, I don't know the answer to that." {
+		t.Errorf("Expected no correction for \"is\", but got %q", answer)
+	}
+}
+
+// Test kata dalam kamus pengguna tidak pernah dikoreksi
+func TestTypoCorrectionDictionary(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("reset password", []string{"Open settings"}, "")
+
+	if answer := ai.Ask("passwerd"); answer != "Open settings" {
+		t.Fatalf("Expected Open settings, but got %q", answer)
+	}
+
+	if err := ai.SetDictionary("passwerd"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if answer := ai.Ask("passwerd"); answer == "Open settings" {
+		t.Errorf("Expected %q to be protected from correction", "passwerd")
+	}
+}
+
+// Test kandidat dengan jarak sama dipilih berdasarkan frekuensi kata
+func TestTypoCorrectionFrequency(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("bake", []string{"Oven"}, "")
+	ai.Train("cake recipe", []string{"Recipe"}, "")
+	ai.Train("cake shop", []string{"Shop"}, "")
+
+	response, err := ai.Respond(context.Background(), "dake")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(response.Corrections) != 1 || response.Corrections[0].Corrected != "cake" {
+		t.Errorf("Expected correction to cake, but got %+v", response.Corrections)
+	}
+}
+
+// Test Response melaporkan koreksi yang dilakukan
+func TestRespondCorrections(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is the capital of France?", []string{"Paris"}, "")
+
+	response, err := ai.Respond(context.Background(), "capitl of France?")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.Text != "Paris" {
+		t.Errorf("Expected Paris, but got %q", response.Text)
+	}
+	if response.Corrected != "capital of France?" {
+		t.Errorf("Expected corrected input %q, but got %q", "capital of France?", response.Corrected)
+	}
+	if len(response.Corrections) != 1 {
+		t.Fatalf("Expected 1 correction, but got %+v", response.Corrections)
+	}
+	correction := response.Corrections[0]
+	if correction.Original != "capitl" || correction.Corrected != "capital" {
+		t.Errorf("Unexpected correction %+v", correction)
+	}
+	if correction.Confidence <= 0 || correction.Confidence > 1 {
+		t.Errorf("Expected confidence between 0 and 1, but got %v", correction.Confidence)
+	}
+
+	response, _ = ai.Respond(context.Background(), "capital of France?")
+	if len(response.Corrections) != 0 || response.Corrected != "" {
+		t.Errorf("Expected no corrections, but got %+v", response)
+	}
+}
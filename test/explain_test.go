@@ -0,0 +1,138 @@
+package test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test Explain mencatat token, koreksi, rentang token, dan kontribusi kata
+func TestExplain(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("How do I reset my password?", []string{"Hi %user|friend%, use the reset link."}, "")
+	ai.Train("How do I change my email?", []string{"Open settings."}, "")
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+
+	explanation, err := ai.Explain(context.Background(), "reset pasword? where is office")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(explanation.Segments) != 2 {
+		t.Fatalf("Expected 2 segments, but got %d", len(explanation.Segments))
+	}
+
+	segment := explanation.Segments[0]
+	if strings.Join(segment.Tokens, " ") != "reset pasword" || strings.Join(segment.Corrected, " ") != "reset password" {
+		t.Errorf("Unexpected tokens %q -> %q", segment.Tokens, segment.Corrected)
+	}
+	if len(segment.Windows) != 2 {
+		t.Errorf("Expected 2 windows, but got %+v", segment.Windows)
+	}
+	if len(segment.Matches) != 1 {
+		t.Fatalf("Expected 1 match, but got %+v", segment.Matches)
+	}
+
+	m := segment.Matches[0]
+	if m.Question != "How do I reset my password?" || m.Start != 0 || m.End != 2 {
+		t.Errorf("Unexpected match %+v", m)
+	}
+	total := 0.0
+	for _, term := range m.Terms {
+		total += term.Contribution
+	}
+	if len(m.Terms) != 2 || total < m.WordScore-1e-9 || total > m.WordScore+1e-9 {
+		t.Errorf("Expected term contributions to add up to %v, but got %+v", m.WordScore, m.Terms)
+	}
+
+	if len(explanation.Substitutions) != 1 || explanation.Substitutions[0].Value != "friend" {
+		t.Errorf("Unexpected substitutions %+v", explanation.Substitutions)
+	}
+	if explanation.Response.Text != "Hi friend, use the reset link. Jakarta." {
+		t.Errorf("Unexpected answer %q", explanation.Response.Text)
+	}
+
+	report := explanation.String()
+	for _, expected := range []string{"pasword -> password", "How do I reset my password?", "%user|friend% -> \"friend\"", "Answer: Hi friend"} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Expected report to contain %q, got:\n%s", expected, report)
+		}
+	}
+}
+
+// Test Explain menampilkan pembanding dan tidak mengubah sesi
+func TestExplainRunnersUpAndSession(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("reset password", []string{"Use the reset link."}, "")
+	ai.Train("reset account", []string{"Contact support."}, "")
+	ai.Train("opening hours", []string{"Nine to five."}, "")
+	if err := ai.SetClarification(beo.Clarification{Margin: 0.05}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := beo.WithSession(context.Background(), beo.NewSession())
+	if _, err := ai.AskContext(ctx, "reset"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	explanation, err := ai.Explain(ctx, "reset password")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	runnersUp := explanation.Segments[0].Matches[0].RunnersUp
+	if len(runnersUp) != 1 || runnersUp[0].Question.Question != "reset account" {
+		t.Errorf("Expected reset account as runner-up, but got %+v", runnersUp)
+	}
+
+	// Klarifikasi yang tertunda masih dapat dipilih setelah Explain
+	if answer, _ := ai.AskContext(ctx, "2"); answer != "Contact support." {
+		t.Errorf("Expected pending clarification to survive Explain, but got %q", answer)
+	}
+}
+
+// Test Explain memakai pertanyaan pemenang meskipun teksnya sama dengan pertanyaan lain
+func TestExplainDuplicateQuestionText(t *testing.T) {
+	file, err := os.CreateTemp("", "knowledgebase_test_*.yml")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(`questions:
+  - question: Help
+    aliases: [reset my password]
+    answers: [Use the reset link.]
+  - question: Help
+    aliases: [track my order]
+    answers: [Open the orders page.]
+  - question: Where is the office?
+    answers: [Jakarta.]
+`)
+	if err != nil {
+		t.Fatalf("Error writing temp file: %v", err)
+	}
+	file.Seek(0, 0)
+
+	ai, err := beo.NewAI(file)
+	if err != nil {
+		t.Fatalf("Error initializing AI: %v", err)
+	}
+
+	explanation, err := ai.Explain(context.Background(), "reset my password")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(explanation.Segments) == 0 || len(explanation.Segments[0].Matches) == 0 {
+		t.Fatalf("Expected a match, but got %+v", explanation)
+	}
+	match := explanation.Segments[0].Matches[0]
+	if match.Alias != "reset my password" {
+		t.Errorf("Expected alias %q, but got %q", "reset my password", match.Alias)
+	}
+	if len(match.RunnersUp) != 1 || !reflect.DeepEqual(match.RunnersUp[0].Question.Aliases, []string{"track my order"}) {
+		t.Errorf("Expected the other Help question as runner-up, but got %+v", match.RunnersUp)
+	}
+}
@@ -0,0 +1,154 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test fallback untuk input kosong dan input terlalu panjang
+func TestFallbackInputKinds(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is your name?", []string{"Beo"}, "")
+	ai.KnowledgeBase.Fallbacks.EmptyInput = []string{"Please type a question."}
+	ai.KnowledgeBase.Fallbacks.TooLong = []string{"That is too long for %ainame%."}
+	ai.KnowledgeBase.Fallbacks.MaxLength = 40
+
+	tests := []struct {
+		input    string
+		expected string
+		fallback string
+	}{
+		{"", "Please type a question.", beo.FallbackEmptyInput},
+		{"  ?! ", "Please type a question.", beo.FallbackEmptyInput},
+		{strings.Repeat("what is your name ", 3), "That is too long for Beo Talk.", beo.FallbackTooLong},
+		{"what is your name", "Beo", ""},
+	}
+	for _, test := range tests {
+		response, err := ai.Respond(context.Background(), test.input)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if response.Text != test.expected || response.Fallback != test.fallback {
+			t.Errorf("For %q expected %q (%q), but got %q (%q)", test.input, test.expected, test.fallback, response.Text, response.Fallback)
+		}
+	}
+}
+
+// Test fallback yang tidak dikonfigurasi memakai NoAnswer
+func TestFallbackDefaultsToNoAnswer(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is your name?", []string{"Beo"}, "")
+
+	if answer := ai.Ask(""); answer != ai.KnowledgeBase.Fallbacks.NoAnswer {
+		t.Errorf("Expected NoAnswer for empty input, but got %q", answer)
+	}
+}
+
+// Test beberapa jawaban NoAnswer dipilih secara acak
+func TestFallbackNoAnswers(t *testing.T) {
+	ai := newTestAI(t)
+	ai.KnowledgeBase.Fallbacks.NoAnswer = ""
+	ai.KnowledgeBase.Fallbacks.NoAnswers = []string{"Hmm?", "Pardon?"}
+
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		seen[ai.Ask("something unknown")] = true
+	}
+	if len(seen) != 2 || !seen["Hmm?"] || !seen["Pardon?"] {
+		t.Errorf("Expected both fallbacks, but got %v", seen)
+	}
+}
+
+// Test jawaban dengan skor di bawah ambang batas memakai fallback low-confidence
+func TestFallbackLowConfidence(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("How do I reset my password quickly?", []string{"Use the reset link."}, "")
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+	ai.KnowledgeBase.Fallbacks.Threshold = 0.9
+	ai.KnowledgeBase.Fallbacks.LowConfidence = []string{"Are you asking: %question%"}
+
+	response, err := ai.Respond(context.Background(), "password")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.Text != "Are you asking: How do I reset my password quickly?" || response.Fallback != beo.FallbackLowConfidence {
+		t.Errorf("Unexpected response %+v", response)
+	}
+
+	if answer := ai.Ask("Where is the office?"); answer != "Jakarta." {
+		t.Errorf("Expected confident answer, but got %q", answer)
+	}
+}
+
+// Test fallback jika hanya sebagian pertanyaan yang terjawab
+func TestFallbackPartial(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+	ai.KnowledgeBase.Fallbacks.Partial = []string{"I couldn't answer the rest."}
+
+	response, err := ai.Respond(context.Background(), "Where is the office? Do you like pizza?")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.Text != "Jakarta. I couldn't answer the rest." || response.Fallback != beo.FallbackPartial {
+		t.Errorf("Unexpected response %+v", response)
+	}
+
+	if answer := ai.Ask("Where is the office?"); answer != "Jakarta." {
+		t.Errorf("Expected no partial fallback, but got %q", answer)
+	}
+}
+
+// Test fallback per tag untuk topik percakapan
+func TestFallbackTags(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("How do I pay my invoice?", []string{"Pay from the billing page."}, "")
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+	if err := ai.SetTags("How do I pay my invoice?", "billing"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := ai.SetTags("Unknown question", "billing"); err == nil {
+		t.Error("Expected error for unknown question")
+	}
+	ai.KnowledgeBase.Fallbacks.Tags = map[string][]string{
+		"billing": {"For billing questions, contact finance@example.com."},
+	}
+
+	ctx := beo.WithSession(context.Background(), beo.NewSession())
+	if answer, _ := ai.AskContext(ctx, "unknown thing"); answer != ai.KnowledgeBase.Fallbacks.NoAnswer {
+		t.Errorf("Expected generic fallback, but got %q", answer)
+	}
+	if answer, _ := ai.AskContext(ctx, "pay my invoice"); answer != "Pay from the billing page." {
+		t.Fatalf("Expected billing answer, but got %q", answer)
+	}
+	if answer, _ := ai.AskContext(ctx, "unknown thing"); answer != "For billing questions, contact finance@example.com." {
+		t.Errorf("Expected billing fallback, but got %q", answer)
+	}
+	if answer, _ := ai.AskContext(ctx, "Where is the office?"); answer != "Jakarta." {
+		t.Fatalf("Expected office answer, but got %q", answer)
+	}
+	if answer, _ := ai.AskContext(ctx, "unknown thing"); answer != ai.KnowledgeBase.Fallbacks.NoAnswer {
+		t.Errorf("Expected generic fallback after topic change, but got %q", answer)
+	}
+}
+
+// Test Validate melaporkan konfigurasi fallback yang tidak valid
+func TestValidateFallbacks(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+	ai.KnowledgeBase.Fallbacks.Threshold = 1.5
+	ai.KnowledgeBase.Fallbacks.Tags = map[string][]string{"billing": {"Ask finance."}}
+
+	err := ai.Validate()
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+	for _, expected := range []string{"threshold", `"billing"`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to mention %s, but got %v", expected, err)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test ReadCSV membaca jawaban ganda, hook, dan tag
+func TestReadCSV(t *testing.T) {
+	input := "question,answers,hook,tags\n" +
+		"What is your name?,I am Beo.||Call me {Beo|the bot}.,,\"bot, intro\"\n" +
+		"What time is it?,,time,\n" +
+		",Skipped.,,\n"
+
+	questions, err := beo.ReadCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []beo.Question{
+		{Question: "What is your name?", Answers: []string{"I am Beo.", "Call me {Beo|the bot}."}, Tags: []string{"bot", "intro"}},
+		{Question: "What time is it?", Hook: "time"},
+	}
+	if !reflect.DeepEqual(questions, expected) {
+		t.Errorf("Expected %+v, but got %+v", expected, questions)
+	}
+
+	if _, err := beo.ReadCSV(strings.NewReader("text,answers\nHi,Hello\n")); err == nil {
+		t.Error("Expected an error for a CSV without a question column")
+	}
+}
+
+// Test ReadJSONL menerima answers berupa string atau array dan melaporkan nomor baris
+func TestReadJSONL(t *testing.T) {
+	input := `{"question": "Ping?", "answers": "Pong."}
+
+{"question": "Who are you?", "answers": ["Beo.", "A bot."], "tags": ["bot"]}
+`
+	questions, err := beo.ReadJSONL(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []beo.Question{
+		{Question: "Ping?", Answers: []string{"Pong."}},
+		{Question: "Who are you?", Answers: []string{"Beo.", "A bot."}, Tags: []string{"bot"}},
+	}
+	if !reflect.DeepEqual(questions, expected) {
+		t.Errorf("Expected %+v, but got %+v", expected, questions)
+	}
+
+	_, err = beo.ReadJSONL(strings.NewReader("{\"question\": \"Ok?\", \"answers\": \"Ok.\"}\n{\"answers\": \"No question.\"}\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error on line 2, but got %v", err)
+	}
+}
+
+// Test ReadMarkdownFAQ memakai heading sebagai pertanyaan dan melewati heading tanpa isi
+func TestReadMarkdownFAQ(t *testing.T) {
+	input := "# FAQ\n\n## Account\n\n### How do I reset my password?\n\nOpen settings.\n\n" +
+		"Then click reset.\n\n```\n# not a heading\n```\n\n### Where is the office? ###\nJakarta.\n"
+
+	questions, err := beo.ReadMarkdownFAQ(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []beo.Question{
+		{Question: "How do I reset my password?", Answers: []string{"Open settings.\n\nThen click reset.\n\n```\n# not a heading\n```"}},
+		{Question: "Where is the office?", Answers: []string{"Jakarta."}},
+	}
+	if !reflect.DeepEqual(questions, expected) {
+		t.Errorf("Expected %+v, but got %+v", expected, questions)
+	}
+}
+
+// Test Import mode merge menambahkan pertanyaan dan menggabungkan jawaban
+func TestImportMerge(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is your name?", []string{"I am Beo."}, "")
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+
+	report, err := ai.Import([]beo.Question{
+		{Question: "What is your name?", Answers: []string{"I am Beo.", "Call me Beo."}},
+		{Question: "Where is the office?", Answers: []string{"Jakarta."}},
+		{Question: "Who made you?", Answers: []string{"A trainer."}},
+		{Question: "Who made you?", Answers: []string{"Someone."}},
+	}, beo.ImportMerge, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := beo.ImportReport{
+		Added:     []string{"Who made you?"},
+		Updated:   []string{"What is your name?"},
+		Unchanged: []string{"Where is the office?"},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Expected report %+v, but got %+v", expected, report)
+	}
+
+	questions := ai.KnowledgeBase.Questions
+	if len(questions) != 3 {
+		t.Fatalf("Expected 3 questions, but got %+v", questions)
+	}
+	if !reflect.DeepEqual(questions[0].Answers, []string{"I am Beo.", "Call me Beo."}) {
+		t.Errorf("Expected merged answers, but got %q", questions[0].Answers)
+	}
+	if !reflect.DeepEqual(questions[2].Answers, []string{"A trainer.", "Someone."}) {
+		t.Errorf("Expected duplicate imports to be merged, but got %q", questions[2].Answers)
+	}
+
+	// Pertanyaan hasil impor langsung dapat dijawab
+	answer := ai.Ask("who made you")
+	if answer != "A trainer." && answer != "Someone." {
+		t.Errorf("Expected an imported answer, but got %q", answer)
+	}
+}
+
+// Test Import mode replace dan dry-run
+func TestImportReplaceDryRun(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is your name?", []string{"I am Beo."}, "")
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+
+	imported := []beo.Question{
+		{Question: "What is your name?", Answers: []string{"I am Beo."}},
+		{Question: "Ping?", Answers: []string{"Pong."}},
+	}
+	report, err := ai.Import(imported, beo.ImportReplace, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := beo.ImportReport{
+		Added:     []string{"Ping?"},
+		Unchanged: []string{"What is your name?"},
+		Removed:   []string{"Where is the office?"},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Expected report %+v, but got %+v", expected, report)
+	}
+	if len(ai.KnowledgeBase.Questions) != 2 || ai.KnowledgeBase.Questions[1].Question != "Where is the office?" {
+		t.Fatalf("Expected dry run to leave questions unchanged, but got %+v", ai.KnowledgeBase.Questions)
+	}
+
+	if _, err := ai.Import(imported, beo.ImportReplace, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(ai.KnowledgeBase.Questions) != 2 || ai.KnowledgeBase.Questions[1].Question != "Ping?" {
+		t.Errorf("Expected questions to be replaced, but got %+v", ai.KnowledgeBase.Questions)
+	}
+	if answer := ai.Ask("where is the office"); answer == "Jakarta." {
+		t.Errorf("Expected removed question not to be answered, but got %q", answer)
+	}
+}
+
+// Test Import menolak mode yang tidak dikenal dan pertanyaan tanpa jawaban
+func TestImportInvalid(t *testing.T) {
+	ai := newTestAI(t)
+
+	if _, err := ai.Import(nil, "append", false); err == nil {
+		t.Error("Expected an error for an unknown mode")
+	}
+	_, err := ai.Import([]beo.Question{{Question: "Empty?"}}, beo.ImportMerge, false)
+	if err == nil || !strings.Contains(err.Error(), "Empty?") {
+		t.Errorf("Expected an error naming the question, but got %v", err)
+	}
+	if len(ai.KnowledgeBase.Questions) != 0 {
+		t.Errorf("Expected no questions after a failed import, but got %+v", ai.KnowledgeBase.Questions)
+	}
+}
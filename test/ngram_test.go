@@ -0,0 +1,36 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test n-gram karakter mencocokkan kata yang digabung atau dipisah
+func TestCharacterNGrams(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("How do I reset my password?", []string{"Use the reset link."}, "")
+	ai.Train("Where is the checkout page?", []string{"Top right corner."}, "")
+
+	if answer := ai.Ask("passwordreset"); answer != ai.KnowledgeBase.Fallbacks.NoAnswer {
+		t.Fatalf("Expected no word-level match without n-grams, got %q", answer)
+	}
+
+	if err := ai.SetMatching(beo.Matching{NGram: 3, NGramWeight: 0.5}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"passwordreset", "Use the reset link."},
+		{"check out", "Top right corner."},
+	}
+	for _, test := range tests {
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, answer)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test tahap pipeline bawaan dan kustom diterapkan pada pertanyaan dan input
+func TestPipeline(t *testing.T) {
+	beo.RegisterTextFilter("drop-please", beo.TextFilterFunc(func(tokens []string) []string {
+		var result []string
+		for _, token := range tokens {
+			if token != "please" {
+				result = append(result, token)
+			}
+		}
+		return result
+	}))
+
+	ai := newTestAI(t)
+	if err := ai.SetPipeline("numbers", "emoji", "drop-please"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ai.Train("I want 2 pizza", []string{"Two pizzas coming up."}, "")
+	ai.Train("Where is the coffee machine", []string{"Next to the kitchen."}, "")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"please I want two 🍕", "Two pizzas coming up."},
+		{"where is the ☕ machine", "Next to the kitchen."},
+	}
+	for _, test := range tests {
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, answer)
+		}
+	}
+}
+
+// Test tahap pipeline yang tidak dikenal ditolak saat memuat knowledge base
+func TestPipelineUnknownStage(t *testing.T) {
+	file, err := os.CreateTemp("", "knowledgebase_test_*.yml")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString("pipeline: [numbers, nope]\n"); err != nil {
+		t.Fatalf("Error writing temp file: %v", err)
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		t.Fatalf("Error seeking temp file: %v", err)
+	}
+
+	_, err = beo.NewAI(file)
+	if err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Errorf("Expected unknown stage error, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test placeholder dinamis dan slot per permintaan diganti pada jawaban
+func TestRegisterPlaceholder(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Who am I?", []string{"You are %user%, uptime %uptime%."}, "")
+	ai.RegisterPlaceholder("uptime", func(ctx context.Context) string {
+		return "5m"
+	})
+
+	ctx := beo.WithSlots(context.Background(), beo.Slots{"user": "Budi"})
+	answer, err := ai.AskContext(ctx, "Who am I?")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedAnswer := "You are Budi, uptime 5m."
+	if answer != expectedAnswer {
+		t.Errorf("Expected answer %v, but got %v", expectedAnswer, answer)
+	}
+}
+
+// Test urutan prioritas placeholder: slot, penyedia dinamis, statis, lalu bawaan
+func TestPlaceholderPrecedence(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Who are you?", []string{"%ainame%/%name%/%greeting%"}, "")
+	ai.AddPlaceholder("ainame", "Static")
+	ai.AddPlaceholder("name", "Static")
+	ai.AddPlaceholder("greeting", "Static")
+	ai.RegisterPlaceholder("name", func(ctx context.Context) string { return "Provider" })
+	ai.RegisterPlaceholder("greeting", func(ctx context.Context) string { return "Provider" })
+
+	ctx := beo.WithSlots(context.Background(), beo.Slots{"greeting": "Slot"})
+	answer, err := ai.AskContext(ctx, "Who are you?")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedAnswer := "Static/Provider/Slot"
+	if answer != expectedAnswer {
+		t.Errorf("Expected answer %v, but got %v", expectedAnswer, answer)
+	}
+}
+
+// Test placeholder berargumen, nilai default, dan tanda persen literal
+func TestPlaceholderFunctions(t *testing.T) {
+	ai := newTestAI(t)
+	ai.AddPlaceholder("name", "budi santoso")
+	ai.KnowledgeBase.Formats.TimeZone = "UTC"
+
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	tests := []struct {
+		answer   string
+		expected string
+	}{
+		{"%upper:name%", "BUDI SANTOSO"},
+		{"%title:name%", "Budi Santoso"},
+		{"Hi %user|friend%", "Hi friend"},
+		{"Hi %name|friend%", "Hi budi santoso"},
+		{"%date:+1d,iso%", tomorrow},
+		{"%upper:missing|NONE%", "NONE"},
+		{"100%% sure", "100% sure"},
+		{"%unknown%", "%unknown%"},
+	}
+
+	for _, test := range tests {
+		ai.KnowledgeBase.Questions = nil
+		ai.Train("Test", []string{test.answer}, "")
+		answer := ai.Ask("Test")
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.answer, test.expected, answer)
+		}
+	}
+}
+
+// Test placeholder waktu dengan zona waktu dan format alternatif
+func TestPlaceholderTimeZone(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Test", []string{"%time:Asia/Jakarta,clock%"}, "")
+
+	zone, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skipf("Time zone data not available: %v", err)
+	}
+	before := time.Now().In(zone).Format("15:04")
+	answer := ai.Ask("Test")
+	after := time.Now().In(zone).Format("15:04")
+	if answer != before && answer != after {
+		t.Errorf("Expected Jakarta time %v, but got %v", before, answer)
+	}
+}
+
+// Test variabel sesi dapat dipakai sebagai placeholder
+func TestSessionPlaceholder(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Who am I?", []string{"You are %user%."}, "")
+
+	session := beo.NewSession()
+	session.Set("user", "Sari")
+	answer, err := ai.AskContext(beo.WithSession(context.Background(), session), "Who am I?")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedAnswer := "You are Sari."
+	if answer != expectedAnswer {
+		t.Errorf("Expected answer %v, but got %v", expectedAnswer, answer)
+	}
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"testing"
+)
+
+// Test Search mengembalikan k pertanyaan teratas berurutan berdasarkan skor
+func TestSearch(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("How do I reset my password?", []string{"Use the reset link."}, "")
+	ai.Train("How do I change my password?", []string{"Open settings."}, "")
+	ai.Train("How do I delete my account?", []string{"Contact support."}, "")
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+
+	results := ai.Search("reset password", 2)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, but got %+v", results)
+	}
+	if results[0].Question.Question != "How do I reset my password?" {
+		t.Errorf("Expected reset question first, but got %q", results[0].Question.Question)
+	}
+	if results[1].Question.Question != "How do I change my password?" {
+		t.Errorf("Expected change question second, but got %q", results[1].Question.Question)
+	}
+	if results[0].Score < results[1].Score {
+		t.Errorf("Expected descending scores, but got %v then %v", results[0].Score, results[1].Score)
+	}
+
+	// Pertanyaan tanpa kata yang sama tidak disertakan
+	for _, result := range ai.Search("reset password", 10) {
+		if result.Question.Question == "Where is the office?" {
+			t.Errorf("Expected unrelated question to be excluded, but got score %v", result.Score)
+		}
+	}
+}
+
+// Test Search memakai koreksi typo dan menangani input kosong
+func TestSearchTypoAndEmpty(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("How do I reset my password?", []string{"Use the reset link."}, "")
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+
+	results := ai.Search("pasword", 1)
+	if len(results) != 1 || results[0].Question.Question != "How do I reset my password?" {
+		t.Errorf("Expected corrected match, but got %+v", results)
+	}
+
+	if results := ai.Search("", 3); len(results) != 0 {
+		t.Errorf("Expected no results for empty query, but got %+v", results)
+	}
+	if results := ai.Search("office", 0); len(results) != 0 {
+		t.Errorf("Expected no results for k = 0, but got %+v", results)
+	}
+}
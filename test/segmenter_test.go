@@ -0,0 +1,111 @@
+package test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test titik pada singkatan, angka, dan domain tidak memecah pertanyaan
+func TestSegmentationAbbreviations(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Who is Dr. Smith?", []string{"Our surgeon."}, "")
+	ai.Train("What changed in v1.2?", []string{"Faster search."}, "")
+	ai.Train("Is example.com safe?", []string{"Yes."}, "")
+	ai.Train("Where do J. Doe and Mrs. Roe work?", []string{"At the lab."}, "")
+	ai.Train("Bring snacks, e.g. chips", []string{"Noted."}, "")
+	ai.Train("Where is gate No. 5?", []string{"Terminal 2."}, "")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Who is Dr. Smith?", "Our surgeon."},
+		{"What changed in v1.2?", "Faster search."},
+		{"Is example.com safe?", "Yes."},
+		{"Where do J. Doe and Mrs. Roe work?", "At the lab."},
+		{"Bring snacks, e.g. chips", "Noted."},
+		{"Where is gate No. 5?", "Terminal 2."},
+		{"Who is Dr. Smith? What changed in v1.2?", "Our surgeon. Faster search."},
+	}
+	for _, test := range tests {
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, answer)
+		}
+	}
+}
+
+// Test "No." hanya dianggap singkatan jika diikuti angka
+func TestSegmentationNumberAbbreviation(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Is it open?", []string{"Until 9 PM."}, "")
+	ai.Train("What is your name?", []string{"I am Beo."}, "")
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"Is it open? No. What is your name", []string{"Is it open", "No", "What is your name"}},
+		{"Is gate No. 5 open?", []string{"Is gate No. 5 open"}},
+	}
+	for _, test := range tests {
+		explanation, err := ai.Explain(context.Background(), test.input)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var segments []string
+		for _, segment := range explanation.Segments {
+			segments = append(segments, segment.Text)
+		}
+		if !reflect.DeepEqual(segments, test.expected) {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, segments)
+		}
+	}
+}
+
+// Test singkatan tambahan per knowledge base
+func TestSegmentationCustomAbbreviations(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Where is Gd. Sate?", []string{"Bandung."}, "")
+
+	if answer := ai.Ask("Where is Gd. Sate?"); answer == "Bandung." {
+		t.Fatalf("Expected unknown abbreviation to split the input, but got %q", answer)
+	}
+
+	ai.SetSegmentation(beo.Segmentation{Abbreviations: []string{"Gd."}})
+	if answer := ai.Ask("Where is Gd. Sate?"); answer != "Bandung." {
+		t.Errorf("Expected Bandung., but got %q", answer)
+	}
+}
+
+// Test pemecahan pada kata hubung jika diaktifkan
+func TestSegmentationConjunctions(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is your name?", []string{"Beo."}, "")
+	ai.Train("How old are you?", []string{"Three years."}, "")
+	ai.Train("salt and pepper", []string{"Seasoning."}, "")
+
+	ai.SetSegmentation(beo.Segmentation{SplitConjunctions: true})
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"what is your name and how old are you", "Beo. Three years."},
+		{"what is your name, and also how old are you", "Beo. Three years."},
+		{"salt and pepper", "Seasoning."},
+	}
+	for _, test := range tests {
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, answer)
+		}
+	}
+
+	ai.SetSegmentation(beo.Segmentation{SplitConjunctions: true, Conjunctions: []string{"plus"}})
+	if answer := ai.Ask("what is your name plus how old are you"); answer != "Beo. Three years." {
+		t.Errorf("Expected custom conjunction split, but got %q", answer)
+	}
+}
@@ -0,0 +1,117 @@
+package test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// Test stemmer bahasa Indonesia mencocokkan kata berimbuhan dengan kata dasarnya
+func TestIndonesianStemmer(t *testing.T) {
+	ai := newTestAI(t)
+	if err := ai.SetStemmer("id"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ai.Train("Di mana saya bisa membeli tiket?", []string{"Di loket."}, "")
+	ai.Train("Bagaimana cara pembayaran?", []string{"Transfer bank."}, "")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"beli tiket dimana", "Di loket."},
+		{"tiket dibeli di mana", "Di loket."},
+		{"cara membayar bagaimana", "Transfer bank."},
+	}
+	for _, test := range tests {
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, answer)
+		}
+	}
+}
+
+// Test stemmer bahasa Indonesia tidak memotong kata dasar yang menyerupai awalan,
+// menghapus akhiran -i setelah awalan, dan mengembalikan k yang luluh setelah meng-/peng-
+func TestIndonesianStemmerAffixes(t *testing.T) {
+	ai := newTestAI(t)
+	if err := ai.SetStemmer("id"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ai.Train("placeholder question", []string{"Answer"}, "")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"kebersihan", "bersih"},
+		{"membersihkan", "bersih"},
+		{"pendidikan", "didik"},
+		{"pengetahuan", "tahu"},
+		{"mengetahui", "tahu"},
+		{"diketahui", "tahu"},
+		{"keadaan", "ada"},
+		{"memperbaiki", "baik"},
+		{"perbaikan", "baik"},
+		{"dikerjakan", "kerja"},
+		{"menyelesaikan", "selesai"},
+		{"keberhasilan", "hasil"},
+		{"mengganti", "ganti"},
+		{"dipakai", "pakai"},
+		{"pergi", "pergi"},
+		{"mengirimkan", "kirim"},
+		{"pengiriman", "kirim"},
+		{"dikirim", "kirim"},
+		{"pekerjaan", "kerja"},
+		{"bekerja", "kerja"},
+		{"mengembangkan", "kembang"},
+		{"mengambil", "ambil"},
+	}
+	for _, test := range tests {
+		explanation, err := ai.Explain(context.Background(), test.input)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(explanation.Segments) != 1 {
+			t.Fatalf("For %q expected one segment, but got %+v", test.input, explanation.Segments)
+		}
+		if tokens := explanation.Segments[0].Tokens; !reflect.DeepEqual(tokens, []string{test.expected}) {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, tokens)
+		}
+	}
+}
+
+// Test stemmer bahasa Inggris (Porter) mencocokkan bentuk kata yang berbeda
+func TestEnglishStemmer(t *testing.T) {
+	ai := newTestAI(t)
+	if err := ai.SetStemmer("en"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ai.Train("How do I cancel my subscription?", []string{"Go to settings."}, "")
+	ai.Train("Where are the prices listed?", []string{"On the pricing page."}, "")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"cancelling subscriptions", "Go to settings."},
+		{"where is the price list", "On the pricing page."},
+	}
+	for _, test := range tests {
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, answer)
+		}
+	}
+}
+
+// Test stemmer yang tidak dikenal dilaporkan sebagai error
+func TestUnknownStemmer(t *testing.T) {
+	ai := newTestAI(t)
+	if err := ai.SetStemmer("fr"); err == nil {
+		t.Error("Expected error for unknown stemmer, got nil")
+	}
+	if ai.KnowledgeBase.Stemmer != "" {
+		t.Errorf("Expected stemmer to be unchanged, got %q", ai.KnowledgeBase.Stemmer)
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test stopword mencegah pertanyaan cocok hanya karena kata umum
+func TestStopwords(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is the price of a ticket?", []string{"Ten dollars."}, "")
+	ai.Train("Where is the station?", []string{"Downtown."}, "")
+	ai.Train("Apa yang dijual di toko?", []string{"Buku."}, "")
+
+	if err := ai.SetStopwords(beo.Stopwords{Languages: []string{"en", "id"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"what is the museum", ai.KnowledgeBase.Fallbacks.NoAnswer},
+		{"apa yang ada", ai.KnowledgeBase.Fallbacks.NoAnswer},
+		{"the price", "Ten dollars."},
+		{"where is the station", "Downtown."},
+		{"dijual apa", "Buku."},
+	}
+	for _, test := range tests {
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, answer)
+		}
+	}
+}
+
+// Test kata tambahan dan pengecualian pada konfigurasi stopword
+func TestStopwordsCustomWords(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Who are you?", []string{"I am Beo."}, "")
+	ai.Train("Tell me about the weather", []string{"Sunny."}, "")
+
+	err := ai.SetStopwords(beo.Stopwords{
+		Languages: []string{"en"},
+		Words:     []string{"tell"},
+		Keep:      []string{"about"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if answer := ai.Ask("who are you"); answer != "I am Beo." {
+		t.Errorf("Expected question made only of stopwords to match, got %q", answer)
+	}
+	if answer := ai.Ask("tell me"); answer != ai.KnowledgeBase.Fallbacks.NoAnswer {
+		t.Errorf("Expected custom stopwords to be ignored, got %q", answer)
+	}
+	if answer := ai.Ask("about weather"); answer != "Sunny." {
+		t.Errorf("Expected kept word to match, got %q", answer)
+	}
+}
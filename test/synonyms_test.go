@@ -0,0 +1,57 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test sinonim kata dan frasa diterapkan pada pertanyaan dan input
+func TestSynonyms(t *testing.T) {
+	ai := newTestAI(t)
+	err := ai.SetSynonyms([][]string{
+		{"price", "cost", "fee"},
+		{"login", "log in", "sign in"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ai.Train("What is the fee?", []string{"Five dollars."}, "")
+	ai.Train("How do I log in?", []string{"Use your email."}, "")
+	ai.Train("Where is the office?", []string{"Jakarta."}, "")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"what is the cost", "Five dollars."},
+		{"price", "Five dollars."},
+		{"how do I sign in", "Use your email."},
+		{"how do i login", "Use your email."},
+	}
+	for _, test := range tests {
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, answer)
+		}
+	}
+}
+
+// Test MatchedSynonyms melaporkan sinonim yang diganti
+func TestMatchedSynonyms(t *testing.T) {
+	ai := newTestAI(t)
+	ai.KnowledgeBase.Synonyms = [][]string{
+		{"price", "cost", "fee"},
+		{"login", "log in", "sign in"},
+	}
+
+	matches := ai.MatchedSynonyms("How do I Sign In? What's the price and the fee?")
+	expected := []beo.SynonymMatch{
+		{Phrase: "sign in", Canonical: "login"},
+		{Phrase: "fee", Canonical: "price"},
+	}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Errorf("Expected %v, but got %v", expected, matches)
+	}
+}
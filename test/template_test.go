@@ -0,0 +1,52 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test mode template dengan kondisi, slot, dan variabel sesi
+func TestTemplateRendering(t *testing.T) {
+	ai := newTestAI(t)
+	ai.KnowledgeBase.Render = beo.RenderTemplate
+	ai.AddPlaceholder("name", "budi")
+	ai.Train("Who am I?", []string{`{{if .Session.vip}}Welcome back{{else}}Hello{{end}}, {{title (.Placeholder "name")}} from {{.Slots.city}}.`}, "")
+
+	session := beo.NewSession()
+	session.Set("vip", "yes")
+	ctx := beo.WithSession(context.Background(), session)
+	ctx = beo.WithSlots(ctx, beo.Slots{"city": "Jakarta"})
+
+	answer, err := ai.AskContext(ctx, "Who am I?")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedAnswer := "Welcome back, Budi from Jakarta."
+	if answer != expectedAnswer {
+		t.Errorf("Expected answer %v, but got %v", expectedAnswer, answer)
+	}
+}
+
+// Test Validate melaporkan template yang tidak valid dan hook yang tidak dikenal
+func TestValidate(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("What is your name?", []string{"I am %ainame%."}, "")
+	if err := ai.Validate(); err != nil {
+		t.Errorf("Expected valid knowledge base, got %v", err)
+	}
+
+	ai.KnowledgeBase.Render = beo.RenderTemplate
+	ai.Train("Broken", []string{"{{if .Input}}unclosed"}, "")
+	ai.Train("Missing hook", nil, "nothing")
+
+	err := ai.Validate()
+	if err == nil {
+		t.Fatal("Expected validation errors, got nil")
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 2 {
+		t.Errorf("Expected 2 validation errors, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// Test tanda baca, apostrof, diakritik, dan karakter lebar penuh dinormalkan
+func TestTokenizerNormalization(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Where is the café?", []string{"Cafe"}, "")
+	ai.Train("What's the price, exactly?", []string{"Price"}, "")
+	ai.Train("How old are you", []string{"Age"}, "")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"where is the cafe", "Cafe"},
+		{"ＷＨＥＲＥ \"is\" the CAFÉ", "Cafe"},
+		{"whats the price exactly", "Price"},
+		{"What’s the \"price\"?", "Price"},
+		{"how old, are you", "Age"},
+	}
+
+	for _, test := range tests {
+		answer := ai.Ask(test.input)
+		if answer != test.expected {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, answer)
+		}
+	}
+}
+
+// Test teks dipecah berdasarkan batas kata Unicode (UAX #29) dan diakritik
+// hanya dihapus dari huruf Latin
+func TestTokenizerWordBoundaries(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("placeholder question", []string{"Answer"}, "")
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"日本語テキスト", []string{"日", "本", "語", "テキスト"}},
+		{"नमस्ते दुनिया", []string{"नमस्ते", "दुनिया"}},
+		{"Ｃａｆé crème", []string{"cafe", "creme"}},
+		{"Ελληνικά", []string{"ελληνικά"}},
+		{"what`s 1,000 v1.2", []string{"whats", "1,000", "v1.2"}},
+	}
+
+	for _, test := range tests {
+		explanation, err := ai.Explain(context.Background(), test.input)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(explanation.Segments) != 1 {
+			t.Fatalf("For %q expected one segment, but got %+v", test.input, explanation.Segments)
+		}
+		if tokens := explanation.Segments[0].Tokens; !reflect.DeepEqual(tokens, test.expected) {
+			t.Errorf("For %q expected %q, but got %q", test.input, test.expected, tokens)
+		}
+	}
+}
+
+// Test kata Katakana dalam kalimat Jepang dapat dicocokkan secara terpisah
+func TestTokenizerJapaneseMatching(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("日本語テキスト", []string{"Japanese text"}, "")
+	ai.Train("英語", []string{"English"}, "")
+
+	if answer := ai.Ask("テキスト"); answer != "Japanese text" {
+		t.Errorf("For %q expected %q, but got %q", "テキスト", "Japanese text", answer)
+	}
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Ismananda/beo"
+)
+
+// Test variasi inline menghasilkan salah satu kombinasi yang valid
+func TestInlineVariations(t *testing.T) {
+	ai := newTestAI(t)
+	ai.AddPlaceholder("user", "Budi")
+	ai.Train("Hello", []string{"{Hi|Hello} %user%, {how can I help|what do you {need|want}}?"}, "")
+
+	valid := map[string]bool{}
+	for _, greeting := range []string{"Hi", "Hello"} {
+		for _, tail := range []string{"how can I help", "what do you need", "what do you want"} {
+			valid[greeting+" Budi, "+tail+"?"] = true
+		}
+	}
+
+	seen := map[string]bool{}
+	for i := 0; i < 200; i++ {
+		answer := ai.Ask("Hello")
+		if !valid[answer] {
+			t.Fatalf("Unexpected answer %q", answer)
+		}
+		seen[answer] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("Expected variety in answers, got %v", seen)
+	}
+}
+
+// Test escape dan kurung tanpa alternatif dibiarkan literal
+func TestInlineVariationsEscaping(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Hello", []string{`Use \{a\|b\} or {name} or {only}`}, "")
+
+	answer := ai.Ask("Hello")
+	expectedAnswer := "Use {a|b} or {name} or {only}"
+	if answer != expectedAnswer {
+		t.Errorf("Expected answer %v, but got %v", expectedAnswer, answer)
+	}
+}
+
+// Test Seed membuat pemilihan variasi dapat diulang
+func TestSeedVariations(t *testing.T) {
+	ai := newTestAI(t)
+	ai.Train("Hello", []string{"{a|b|c|d|e|f|g|h}{a|b|c|d|e|f|g|h}{a|b|c|d|e|f|g|h}"}, "")
+
+	beo.Seed(42)
+	first := ai.Ask("Hello")
+	beo.Seed(42)
+	second := ai.Ask("Hello")
+	if first != second {
+		t.Errorf("Expected identical answers after reseeding, got %v and %v", first, second)
+	}
+}
@@ -1,33 +1,155 @@
 package beo
 
 import (
+	"context"
 	"math"
+	"sort"
 )
 
-func findBestMatches(inputTokens []string, kb KnowledgeBase) []Question {
-	matches := []Question{}
-	usedTokens := make([]bool, len(inputTokens)) // Tandai token yang sudah digunakan
+// questionVectors berisi vektor TF-IDF kata dan n-gram karakter untuk teks
+// pertanyaan dan setiap aliasnya. Vektor dihitung sekali saat IDF diperbarui
+// dan disimpan di KnowledgeBase agar tidak dihitung ulang pada setiap Ask.
+type questionVectors struct {
+	useIDF bool
+	ngram  int
+	words  [][]map[string]float64
+	chars  [][]map[string]float64
+}
 
-	// Cache TF-IDF untuk pertanyaan dalam KnowledgeBase
-	questionTFIDFCache := make(map[string]map[string]float64)
-	isSingleQuestion := len(kb.Questions) == 1
-	idfAvailable := len(kb.IDF) > 0
-	for _, question := range kb.Questions {
-		questionTokens := tokenize(question.Question)
-		questionTF := termFrequency(questionTokens)
-		if isSingleQuestion || !idfAvailable {
-			// Gunakan TF langsung jika hanya satu pertanyaan atau tidak ada data IDF
-			questionTFIDFCache[question.Question] = questionTF
-		} else {
-			questionTFIDFCache[question.Question] = tfidfScore(questionTF, kb.IDF)
+// newQuestionVectors menghitung vektor TF-IDF seluruh pertanyaan dalam KnowledgeBase
+func (kb *KnowledgeBase) newQuestionVectors() *questionVectors {
+	vectors := &questionVectors{
+		// Gunakan TF langsung jika hanya satu pertanyaan atau tidak ada data IDF
+		useIDF: len(kb.Questions) > 1 && len(kb.IDF) > 0,
+		ngram:  kb.Matching.NGram,
+		words:  make([][]map[string]float64, len(kb.Questions)),
+		chars:  make([][]map[string]float64, len(kb.Questions)),
+	}
+
+	for i, question := range kb.Questions {
+		for _, text := range question.texts() {
+			tokens := kb.analyze(text)
+			vectors.words[i] = append(vectors.words[i], weigh(termFrequency(tokens), kb.IDF, vectors.useIDF))
+			if vectors.ngram > 0 {
+				vectors.chars[i] = append(vectors.chars[i], weigh(termFrequency(charNGrams(tokens, vectors.ngram)), kb.CharIDF, vectors.useIDF))
+			}
+		}
+	}
+	return vectors
+}
+
+// vectors mengembalikan vektor pertanyaan. Jika pertanyaan atau n-gram diubah
+// langsung tanpa updateIDF, vektor dihitung ulang.
+func (kb *KnowledgeBase) vectors() *questionVectors {
+	if kb.questionVectors == nil || len(kb.questionVectors.words) != len(kb.Questions) ||
+		kb.questionVectors.ngram != kb.Matching.NGram {
+		return kb.newQuestionVectors()
+	}
+	return kb.questionVectors
+}
+
+// scorer menghitung kemiripan antara token input dan setiap pertanyaan
+// dengan menggabungkan skor TF-IDF kata dan n-gram karakter. words dan chars
+// berisi satu vektor untuk teks pertanyaan dan setiap aliasnya.
+type scorer struct {
+	*questionVectors
+	kb          *KnowledgeBase
+	ngramWeight float64
+}
+
+// newScorer menyiapkan scorer dari vektor pertanyaan KnowledgeBase
+func newScorer(kb *KnowledgeBase) *scorer {
+	return &scorer{
+		questionVectors: kb.vectors(),
+		kb:              kb,
+		ngramWeight:     kb.Matching.ngramWeight(),
+	}
+}
+
+// weigh mengalikan TF dengan IDF jika IDF dapat digunakan
+func weigh(tf, idf map[string]float64, useIDF bool) map[string]float64 {
+	if !useIDF {
+		return tf
+	}
+	return tfidfScore(tf, idf)
+}
+
+// inputVectors menghitung vektor kata dan n-gram karakter untuk token input
+func (s *scorer) inputVectors(tokens []string) (map[string]float64, map[string]float64) {
+	words := weigh(termFrequency(tokens), s.kb.IDF, s.useIDF)
+	if s.ngram <= 0 {
+		return words, nil
+	}
+	return words, weigh(termFrequency(charNGrams(tokens, s.ngram)), s.kb.CharIDF, s.useIDF)
+}
+
+// similarity menghitung kemiripan vektor input dengan pertanyaan ke-i, yaitu
+// skor tertinggi dari teks pertanyaan dan aliasnya
+func (s *scorer) similarity(words, chars map[string]float64, i int) float64 {
+	_, score := s.bestText(words, chars, i)
+	return score
+}
+
+// bestText mengembalikan urutan teks pertanyaan ke-i (0 untuk Question, lalu
+// alias) yang paling mirip dengan input beserta skornya
+func (s *scorer) bestText(words, chars map[string]float64, i int) (int, float64) {
+	best, bestScore := 0, -1.0
+	for j := range s.words[i] {
+		if score := s.textSimilarity(words, chars, i, j); score > bestScore {
+			best, bestScore = j, score
 		}
 	}
+	return best, bestScore
+}
+
+// textSimilarity menghitung kemiripan vektor input dengan teks ke-j pertanyaan ke-i
+func (s *scorer) textSimilarity(words, chars map[string]float64, i, j int) float64 {
+	wordSimilarity := cosineSimilarity(words, s.words[i][j])
+	if s.ngram <= 0 {
+		return wordSimilarity
+	}
+	charSimilarity := cosineSimilarity(chars, s.chars[i][j])
+	return (1-s.ngramWeight)*wordSimilarity + s.ngramWeight*charSimilarity
+}
+
+// match adalah pertanyaan terbaik untuk satu rentang token beserta skornya.
+// alternatives berisi pertanyaan lain yang skornya berada dalam margin
+// klarifikasi dari skor terbaik, diurutkan dari skor tertinggi.
+type match struct {
+	question     Question
+	index        int
+	score        float64
+	alternatives []Question
+
+	// start dan end adalah rentang token input yang cocok
+	start, end int
+
+	// segment dan input adalah urutan dan teks kalimat input asal pertanyaan
+	segment int
+	input   string
+}
+
+// findBestMatches mencari pertanyaan yang paling cocok untuk setiap rentang token.
+// Pencarian dihentikan dan error dikembalikan jika ctx dibatalkan.
+func findBestMatches(ctx context.Context, inputTokens []string, kb KnowledgeBase) ([]match, error) {
+	matches := []match{}
+	usedTokens := make([]bool, len(inputTokens)) // Tandai token yang sudah digunakan
+
+	// Vektor TF-IDF pertanyaan diambil dari cache KnowledgeBase
+	scorer := newScorer(&kb)
+	trace := traceFromContext(ctx)
 
 	start := 0
 	for start < len(inputTokens) {
-		var bestMatch Question
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		bestIndex := -1
 		highestSimilarity := 0.0
 		bestMatchLength := 0
+		// Skor tertinggi setiap pertanyaan pada posisi ini, untuk klarifikasi
+		scores := make([]float64, len(kb.Questions))
 
 		// Tentukan panjang maksimum subTokens yang masuk akal
 		maxLength := min(10, len(inputTokens)-start) // Misalnya, maksimal 10 kata
@@ -40,28 +162,35 @@ func findBestMatches(inputTokens []string, kb KnowledgeBase) []Question {
 				continue
 			}
 
-			subTokens := inputTokens[start:end]
-			subTF := termFrequency(subTokens)
-			var subTFIDF map[string]float64
-			if isSingleQuestion || !idfAvailable {
-				subTFIDF = subTF
-			} else {
-				subTFIDF = tfidfScore(subTF, kb.IDF)
-			}
+			subWords, subChars := scorer.inputVectors(inputTokens[start:end])
 
-			for _, question := range kb.Questions {
-				questionTFIDF := questionTFIDFCache[question.Question]
-				similarity := cosineSimilarity(subTFIDF, questionTFIDF)
+			window := Window{Start: start, End: end, Tokens: inputTokens[start:end]}
+			for i, question := range kb.Questions {
+				similarity := scorer.similarity(subWords, subChars, i)
+				scores[i] = max(scores[i], similarity)
 				if similarity > highestSimilarity {
 					highestSimilarity = similarity
-					bestMatch = question
+					bestIndex = i
 					bestMatchLength = length
 				}
+				if similarity > window.Score {
+					window.Question, window.Score = question.Question, similarity
+				}
+			}
+			if trace != nil {
+				trace.windows = append(trace.windows, window)
 			}
 		}
 
 		if highestSimilarity > 0.1 {
-			matches = append(matches, bestMatch)
+			matches = append(matches, match{
+				question:     kb.Questions[bestIndex],
+				index:        bestIndex,
+				score:        highestSimilarity,
+				alternatives: alternativeMatches(kb, scores, bestIndex),
+				start:        start,
+				end:          start + bestMatchLength,
+			})
 			markUsedRange(usedTokens, start, start+bestMatchLength)
 			start += bestMatchLength
 		} else {
@@ -69,7 +198,32 @@ func findBestMatches(inputTokens []string, kb KnowledgeBase) []Question {
 		}
 	}
 
-	return matches
+	return matches, nil
+}
+
+// alternativeMatches mengumpulkan pertanyaan selain pertanyaan terbaik yang
+// skornya tidak lebih dari margin klarifikasi di bawah skor terbaik
+func alternativeMatches(kb KnowledgeBase, scores []float64, bestIndex int) []Question {
+	margin := kb.Clarification.Margin
+	if margin <= 0 {
+		return nil
+	}
+
+	var indexes []int
+	for i, score := range scores {
+		if i != bestIndex && score > 0.1 && scores[bestIndex]-score <= margin {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return scores[indexes[a]] > scores[indexes[b]]
+	})
+
+	alternatives := make([]Question, 0, len(indexes))
+	for _, i := range indexes {
+		alternatives = append(alternatives, kb.Questions[i])
+	}
+	return alternatives
 }
 
 // Cek apakah rentang token sudah digunakan
@@ -0,0 +1,116 @@
+package beo
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeText menormalkan Unicode ke NFKC, menghapus diakritik pada huruf
+// Latin, dan mengubah teks menjadi huruf kecil, sehingga "ｃａｆé" dan "cafe"
+// menghasilkan token yang sama. Tanda pada aksara lain seperti Devanagari dan
+// Thai adalah bagian dari huruf, sehingga dipertahankan.
+func normalizeText(text string) string {
+	var b strings.Builder
+	latin := false
+	for _, r := range norm.NFKD.String(text) {
+		if unicode.Is(unicode.Mn, r) {
+			if latin {
+				continue
+			}
+		} else {
+			latin = unicode.Is(unicode.Latin, r)
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(norm.NFKC.String(b.String()))
+}
+
+// isApostrophe mengecek apakah rune merupakan salah satu varian tanda apostrof
+func isApostrophe(r rune) bool {
+	switch r {
+	case '\'', '’', '‘', 'ʼ', '`', '´':
+		return true
+	}
+	return false
+}
+
+// isSymbolToken mengecek apakah token merupakan simbol seperti emoji, termasuk
+// rangkaian emoji yang digabung dengan zero-width joiner
+func isSymbolToken(token string) bool {
+	symbol := false
+	for _, r := range token {
+		switch {
+		case unicode.Is(unicode.So, r):
+			symbol = true
+		case r != zeroWidthJoiner:
+			return false
+		}
+	}
+	return symbol
+}
+
+// zeroWidthJoiner menggabungkan beberapa emoji menjadi satu, misalnya 👨‍👩‍👧
+const zeroWidthJoiner = '\u200d'
+
+// isEmojiModifier mengecek apakah rune merupakan variation selector atau warna kulit emoji
+func isEmojiModifier(r rune) bool {
+	return r == '\ufe0e' || r == '\ufe0f' || (r >= 0x1f3fb && r <= 0x1f3ff)
+}
+
+// tokenize memecah teks menjadi token kata berdasarkan batas kata Unicode
+// (UAX #29). Tanda baca dibuang, apostrof di dalam kata digabungkan ("what's"
+// menjadi "whats"), angka seperti "1.5" tetap satu token, dan setiap emoji
+// menjadi token tersendiri tanpa variation selector atau warna kulit. Aksara
+// tanpa spasi diperlakukan sesuai UAX #29: setiap ideogram Han dan Hiragana
+// menjadi satu token, sedangkan rangkaian Katakana tetap satu token.
+func tokenize(text string) []string {
+	text = joinApostrophes(normalizeText(text))
+
+	var tokens []string
+	state := -1
+	for len(text) > 0 {
+		var segment string
+		segment, text, state = uniseg.FirstWordInString(text, state)
+
+		switch {
+		case strings.IndexFunc(segment, isWordRune) >= 0:
+			tokens = append(tokens, strings.ReplaceAll(segment, "'", ""))
+		case strings.IndexFunc(segment, isSymbolRune) >= 0:
+			symbol := strings.Map(func(r rune) rune {
+				if isEmojiModifier(r) {
+					return -1
+				}
+				return r
+			}, segment)
+			if isSymbolToken(symbol) {
+				tokens = append(tokens, symbol)
+			}
+		}
+	}
+	return tokens
+}
+
+// isWordRune mengecek apakah rune merupakan huruf atau angka
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// isSymbolRune mengecek apakah rune merupakan simbol seperti emoji
+func isSymbolRune(r rune) bool {
+	return unicode.Is(unicode.So, r)
+}
+
+// joinApostrophes menyeragamkan varian apostrof di antara dua huruf menjadi "'"
+// agar segmentasi kata tidak memecah kata seperti "what’s" atau "what`s"
+func joinApostrophes(text string) string {
+	runes := []rune(text)
+	for i, r := range runes {
+		if isApostrophe(r) && i > 0 && i+1 < len(runes) && unicode.IsLetter(runes[i-1]) && unicode.IsLetter(runes[i+1]) {
+			runes[i] = '\''
+		}
+	}
+	return string(runes)
+}
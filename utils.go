@@ -3,10 +3,31 @@ package beo
 import (
 	"math"
 	"math/rand"
-	"regexp"
-	"strings"
+	"sync"
+	"time"
 )
 
+// Sumber acak bersama untuk pemilihan jawaban dan variasi
+var (
+	randomMu     sync.Mutex
+	randomSource = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Seed mengatur benih sumber acak sehingga pemilihan jawaban dan variasi
+// dapat diulang dengan hasil yang sama
+func Seed(seed int64) {
+	randomMu.Lock()
+	defer randomMu.Unlock()
+	randomSource.Seed(seed)
+}
+
+// randomIntn mengembalikan bilangan acak dalam rentang [0, n)
+func randomIntn(n int) int {
+	randomMu.Lock()
+	defer randomMu.Unlock()
+	return randomSource.Intn(n)
+}
+
 // Mengecek apakah sebuah item terkandung dalam slice
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -37,7 +58,10 @@ func cosineSimilarity(vec1, vec2 map[string]float64) float64 {
 
 // levenshtein menghitung jarak Levenshtein antara dua string
 // Jarak Levenshtein adalah jumlah operasi penyuntingan minimum (penyisipan, penghapusan, atau penggantian) yang diperlukan untuk mengubah satu string menjadi string lainnya.
-func levenshtein(a, b string) int {
+// Jarak dihitung per rune, sehingga satu huruf beraksen dihitung sebagai satu karakter.
+func levenshtein(s1, s2 string) int {
+	a, b := []rune(s1), []rune(s2)
+
 	// n dan m adalah panjang dari string a dan b
 	n, m := len(a), len(b)
 	if n == 0 {
@@ -101,25 +125,5 @@ func randomChoice(choices []string) string {
 	if choiceLength == 0 {
 		return ""
 	}
-	return choices[rand.Intn(choiceLength)]
-}
-
-// Pisah input berdasarkan tanda baca
-func splitByPunctuation(input string) []string {
-	re := regexp.MustCompile(`[.?!\n]+`) // Pencocokan tanda baca
-	segments := re.Split(input, -1)
-	cleanedSegments := []string{}
-	for _, segment := range segments {
-		segment = strings.TrimSpace(segment) // Hilangkan spasi kosong
-		if segment != "" {
-			cleanedSegments = append(cleanedSegments, segment)
-		}
-	}
-	return cleanedSegments
-}
-
-// tokenize mengubah
-func tokenize(text string) []string {
-	text = strings.ToLower(text)
-	return strings.Fields(text)
+	return choices[randomIntn(choiceLength)]
 }
@@ -0,0 +1,93 @@
+package beo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Validate memeriksa knowledge base dan mengembalikan gabungan seluruh masalah
+// yang ditemukan, atau nil jika knowledge base valid
+func (ai *AI) Validate() error {
+	var errs []error
+	kb := &ai.KnowledgeBase
+
+	switch kb.Render {
+	case "", RenderPlaceholders, RenderTemplate:
+	default:
+		errs = append(errs, fmt.Errorf("unknown render mode %q", kb.Render))
+	}
+
+	if _, err := kb.buildPipeline(); err != nil {
+		errs = append(errs, err)
+	}
+
+	switch kb.Matching.Distance {
+	case "", DistanceLevenshtein, DistanceDamerau:
+	default:
+		errs = append(errs, fmt.Errorf("unknown distance %q", kb.Matching.Distance))
+	}
+
+	if _, err := kb.Clarification.parseTemplate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid clarification template: %v", err))
+	}
+
+	errs = append(errs, kb.validateFallbacks()...)
+
+	if err := kb.Composition.validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if _, err := time.LoadLocation(kb.Formats.TimeZone); err != nil {
+		errs = append(errs, fmt.Errorf("invalid time zone %q: %v", kb.Formats.TimeZone, err))
+	}
+
+	for i, question := range kb.Questions {
+		if strings.TrimSpace(question.Question) == "" {
+			errs = append(errs, fmt.Errorf("question #%d is empty", i+1))
+		}
+		for _, alias := range question.Aliases {
+			if strings.TrimSpace(alias) == "" {
+				errs = append(errs, fmt.Errorf("question %q has an empty alias", question.Question))
+			}
+		}
+
+		if question.Hook != "" {
+			_, static := kb.Hooks[question.Hook]
+			_, dynamic := ai.hookFuncs[question.Hook]
+			if !static && !dynamic {
+				errs = append(errs, fmt.Errorf("question %q uses unknown hook %q", question.Question, question.Hook))
+			}
+		} else if len(question.Answers) == 0 {
+			errs = append(errs, fmt.Errorf("question %q has no answers or hook", question.Question))
+		}
+
+		if kb.Render == RenderTemplate {
+			for _, answer := range question.Answers {
+				if _, err := parseAnswerTemplate(answer); err != nil {
+					errs = append(errs, fmt.Errorf("question %q has an invalid template: %v", question.Question, err))
+				}
+			}
+		}
+	}
+
+	if kb.Render == RenderTemplate {
+		hookNames := make([]string, 0, len(kb.Hooks))
+		for name := range kb.Hooks {
+			hookNames = append(hookNames, name)
+		}
+		sort.Strings(hookNames)
+
+		for _, name := range hookNames {
+			for _, answer := range kb.Hooks[name].Answers {
+				if _, err := parseAnswerTemplate(answer); err != nil {
+					errs = append(errs, fmt.Errorf("hook %q has an invalid template: %v", name, err))
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,90 @@
+package beo
+
+import (
+	"strings"
+)
+
+// expandVariations memilih salah satu alternatif dari setiap grup variasi
+// seperti {Hai|Halo|Hei} secara acak. Grup boleh bersarang, dan karakter
+// \{ \} \| \\ dipakai untuk menulis karakter literal. Kurung tanpa | (misalnya {nama})
+// dan blok template {{ ... }} dibiarkan apa adanya.
+func expandVariations(text string) string {
+	if !strings.ContainsRune(text, '{') && !strings.ContainsRune(text, '\\') {
+		return text
+	}
+	result, _ := expandSequence([]rune(text), 0, false)
+	return result
+}
+
+// expandSequence memproses teks mulai dari posisi i. Jika berada di dalam grup,
+// pemrosesan berhenti pada | atau } di tingkat yang sama.
+func expandSequence(runes []rune, i int, inGroup bool) (string, int) {
+	var builder strings.Builder
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`{}|\`, runes[i+1]):
+			builder.WriteRune(runes[i+1])
+			i += 2
+
+		case r == '{' && i+1 < len(runes) && runes[i+1] == '{':
+			// Blok template disalin apa adanya
+			end := closingBraces(runes, i+2)
+			if end < 0 {
+				builder.WriteString(string(runes[i:]))
+				return builder.String(), len(runes)
+			}
+			builder.WriteString(string(runes[i : end+2]))
+			i = end + 2
+
+		case r == '{':
+			alternatives, next, ok := expandGroup(runes, i+1)
+			if !ok {
+				builder.WriteRune(r)
+				i++
+				continue
+			}
+			if len(alternatives) == 1 {
+				builder.WriteString("{" + alternatives[0] + "}")
+			} else {
+				builder.WriteString(randomChoice(alternatives))
+			}
+			i = next
+
+		case inGroup && (r == '|' || r == '}'):
+			return builder.String(), i
+
+		default:
+			builder.WriteRune(r)
+			i++
+		}
+	}
+	return builder.String(), i
+}
+
+// expandGroup membaca seluruh alternatif dalam satu grup sampai } penutup.
+// Nilai bool bernilai false jika grup tidak ditutup.
+func expandGroup(runes []rune, i int) ([]string, int, bool) {
+	var alternatives []string
+	for {
+		alternative, next := expandSequence(runes, i, true)
+		if next >= len(runes) {
+			return nil, 0, false
+		}
+		alternatives = append(alternatives, alternative)
+		if runes[next] == '}' {
+			return alternatives, next + 1, true
+		}
+		i = next + 1
+	}
+}
+
+// closingBraces mencari posisi }} pertama mulai dari posisi start, atau -1 jika tidak ada
+func closingBraces(runes []rune, start int) int {
+	for i := start; i+1 < len(runes); i++ {
+		if runes[i] == '}' && runes[i+1] == '}' {
+			return i
+		}
+	}
+	return -1
+}